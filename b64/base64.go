@@ -1,6 +1,7 @@
 package b64
 
 import (
+	"bufio"
 	"encoding/base64"
 	"io"
 
@@ -8,7 +9,20 @@ import (
 )
 
 // Encode outputs base64 encoding string from raw data.
-func Encode(forURL, noPadding bool, r io.Reader, w io.Writer) (err error) {
+func Encode(forURL, noPadding bool, r io.Reader, w io.Writer) error {
+	return EncodeWithOptions(forURL, noPadding, r, w, nil)
+}
+
+// EncodeWithOptions outputs base64 encoding string from raw data with options.
+// If line length is set, encoded lines are wrapped and terminated by LF.
+func EncodeWithOptions(forURL, noPadding bool, r io.Reader, w io.Writer, opts *Options) (err error) {
+	if size := opts.lineLength(); size > 0 {
+		lw := &lineWriter{w: w, size: size}
+		defer func() {
+			err = errs.Join(err, errs.Wrap(lw.Close()))
+		}()
+		w = lw
+	}
 	wc := base64.NewEncoder(encoder(forURL, noPadding), w)
 	defer func() {
 		err = errs.Join(err, wc.Close())
@@ -20,6 +34,14 @@ func Encode(forURL, noPadding bool, r io.Reader, w io.Writer) (err error) {
 
 // Decode outputs raw data from base64 encoding string.
 func Decode(forURL, noPadding bool, r io.Reader, w io.Writer) error {
+	return DecodeWithOptions(forURL, noPadding, r, w, nil)
+}
+
+// DecodeWithOptions outputs raw data from base64 encoding string with options.
+func DecodeWithOptions(forURL, noPadding bool, r io.Reader, w io.Writer, opts *Options) error {
+	if opts.ignoreSpace() {
+		r = &spaceFilter{r: bufio.NewReader(r)}
+	}
 	if _, err := io.Copy(w, base64.NewDecoder(encoder(forURL, noPadding), r)); err != nil {
 		return errs.Wrap(err)
 	}
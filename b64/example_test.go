@@ -32,7 +32,33 @@ func ExampleDecode() {
 	// Hello World
 }
 
-/* Copyright 2020 Spiegel
+func ExampleEncodeWithOptions() {
+	input := strings.NewReader("Hello World\nHello World\nHello World\n")
+	output := &bytes.Buffer{}
+	if err := b64.EncodeWithOptions(false, false, input, output, &b64.Options{LineLength: 16}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(output.String())
+	// Output:
+	// SGVsbG8gV29ybGQK
+	// SGVsbG8gV29ybGQK
+	// SGVsbG8gV29ybGQK
+}
+
+func ExampleDecodeWithOptions() {
+	input := strings.NewReader("SGVs bG8g\r\n\tV29y bGQK\n")
+	output := &bytes.Buffer{}
+	if err := b64.DecodeWithOptions(false, false, input, output, &b64.Options{IgnoreSpace: true}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(output.String())
+	// Output:
+	// Hello World
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
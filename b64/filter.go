@@ -0,0 +1,90 @@
+package b64
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+//lineWriter is io.WriteCloser which wraps lines after fixed length.
+type lineWriter struct {
+	w    io.Writer
+	size int
+	col  int
+}
+
+var newline = []byte{'\n'}
+
+//Write method is implementation of io.Writer interface.
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if lw.col >= lw.size {
+			if _, err := lw.w.Write(newline); err != nil {
+				return n, err
+			}
+			lw.col = 0
+		}
+		k := min(lw.size-lw.col, len(p))
+		m, err := lw.w.Write(p[:k])
+		n += m
+		lw.col += m
+		if err != nil {
+			return n, err
+		}
+		p = p[k:]
+	}
+	return n, nil
+}
+
+//Close method terminates last line by newline.
+func (lw *lineWriter) Close() error {
+	if lw.col > 0 {
+		lw.col = 0
+		if _, err := lw.w.Write(newline); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//spaceFilter is io.Reader which drops whitespace characters in stream.
+type spaceFilter struct {
+	r *bufio.Reader
+}
+
+//Read method is implementation of io.Reader interface.
+func (sf *spaceFilter) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c, err := sf.r.ReadByte()
+		if err != nil {
+			if n > 0 && errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n', '\v', '\f':
+		default:
+			p[n] = c
+			n++
+		}
+	}
+	return n, nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
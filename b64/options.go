@@ -0,0 +1,41 @@
+package b64
+
+const (
+	MIMELineLength = 76 //line length of BASE64 in MIME (RFC 2045)
+	PEMLineLength  = 64 //line length of BASE64 in PEM (RFC 7468)
+)
+
+//Options is options of encoding/decoding BASE64
+type Options struct {
+	LineLength  int  //wrap encoded lines after LineLength characters in encoding (0: no wrapping)
+	IgnoreSpace bool //ignore whitespace characters (include CR/LF) in decoding
+}
+
+func (opts *Options) lineLength() int {
+	if opts == nil || opts.LineLength < 0 {
+		return 0
+	}
+	return opts.LineLength
+}
+
+func (opts *Options) ignoreSpace() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.IgnoreSpace
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,74 @@
+package basen
+
+import (
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+//Alphabet is type of alphabet (encoding scheme) for baseN encoding
+type Alphabet int
+
+const (
+	Base32    Alphabet = iota //Base32 with standard alphabet in RFC 4648 (default)
+	Base32Hex                 //Base32 with extended hex alphabet in RFC 4648
+	Crockford                 //Crockford's Base32
+	Base58                    //Base58 with Bitcoin alphabet
+	Hex                       //Base16 (hexadecimal)
+)
+
+var alphabetNamesMap = map[string]Alphabet{
+	"base32":    Base32,
+	"base32hex": Base32Hex,
+	"crockford": Crockford,
+	"base58":    Base58,
+	"hex":       Hex,
+}
+
+func (a Alphabet) String() string {
+	return alphabetName(a)
+}
+
+func alphabetName(a Alphabet) string {
+	for key, value := range alphabetNamesMap {
+		if value == a {
+			return key
+		}
+	}
+	return ""
+}
+
+//AlphabetList returns list of alphabets for baseN encoding
+func AlphabetList() []string {
+	return []string{
+		alphabetName(Base32),
+		alphabetName(Base32Hex),
+		alphabetName(Crockford),
+		alphabetName(Base58),
+		alphabetName(Hex),
+	}
+}
+
+//AlphabetOf returns alphabet for baseN encoding from name string
+func AlphabetOf(name string) (Alphabet, error) {
+	if a, ok := alphabetNamesMap[strings.ToLower(name)]; ok {
+		return a, nil
+	}
+	return Base32, errs.Wrap(ecode.ErrInvalidAlphabet, errs.WithContext("name", name))
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,99 @@
+package basen
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var radix58 = big.NewInt(58)
+
+//encodeBase58 outputs Base58 encoding string from raw data.
+//Base58 is not block encoding, so whole data is read on memory.
+func encodeBase58(r io.Reader, w io.Writer) error {
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err := io.WriteString(w, EncodeBase58(buf.Bytes())); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+//decodeBase58 outputs raw data from Base58 encoding string.
+func decodeBase58(r io.Reader, w io.Writer) error {
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return errs.Wrap(err)
+	}
+	b, err := DecodeBase58(string(bytes.TrimRight(buf.Bytes(), "\r\n")))
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err := w.Write(b); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+//EncodeBase58 returns Base58 encoding string (Bitcoin alphabet) of byte string.
+func EncodeBase58(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(b[zeros:])
+	mod := new(big.Int)
+	out := []byte{}
+	for n.Sign() > 0 {
+		n.DivMod(n, radix58, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		out = append(out, base58Alphabet[0])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+//DecodeBase58 returns byte string from Base58 encoding string (Bitcoin alphabet).
+func DecodeBase58(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	n := new(big.Int)
+	for i := zeros; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return nil, errs.Wrap(ecode.ErrInvalidEncodedText, errs.WithContext("offset", i))
+		}
+		n.Mul(n, radix58)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,137 @@
+package basen
+
+import (
+	"bufio"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+
+//Encode outputs baseN encoding string from raw data.
+func Encode(a Alphabet, r io.Reader, w io.Writer) (err error) {
+	var wc io.WriteCloser
+	switch a {
+	case Base32:
+		wc = base32.NewEncoder(base32.StdEncoding, w)
+	case Base32Hex:
+		wc = base32.NewEncoder(base32.HexEncoding, w)
+	case Crockford:
+		wc = base32.NewEncoder(crockfordEncoding, w)
+	case Base58:
+		return encodeBase58(r, w)
+	case Hex:
+		_, err = io.Copy(hex.NewEncoder(w), r)
+		return errs.Wrap(err)
+	default:
+		return errs.Wrap(ecode.ErrInvalidAlphabet, errs.WithContext("alphabet", int(a)))
+	}
+	defer func() {
+		err = errs.Join(err, wc.Close())
+	}()
+	_, err = io.Copy(wc, r)
+	err = errs.Wrap(err)
+	return
+}
+
+//Decode outputs raw data from baseN encoding string.
+//CR and LF characters in encoding string are ignored.
+func Decode(a Alphabet, r io.Reader, w io.Writer) error {
+	var dr io.Reader
+	switch a {
+	case Base32:
+		dr = base32.NewDecoder(base32.StdEncoding, r)
+	case Base32Hex:
+		dr = base32.NewDecoder(base32.HexEncoding, r)
+	case Crockford:
+		dr = base32.NewDecoder(crockfordEncoding, &filterReader{r: bufio.NewReader(r), mapping: crockfordMapping})
+	case Base58:
+		return decodeBase58(r, w)
+	case Hex:
+		dr = hex.NewDecoder(&filterReader{r: bufio.NewReader(r), mapping: newlineMapping})
+	default:
+		return errs.Wrap(ecode.ErrInvalidAlphabet, errs.WithContext("alphabet", int(a)))
+	}
+	if _, err := io.Copy(w, dr); err != nil {
+		return decodeError(err)
+	}
+	return nil
+}
+
+//decodeError returns ecode.ErrInvalidEncodedText if err is error of decoding.
+func decodeError(err error) error {
+	var ce base32.CorruptInputError
+	var he hex.InvalidByteError
+	if errors.As(err, &ce) || errors.As(err, &he) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errs.Wrap(ecode.ErrInvalidEncodedText, errs.WithCause(err))
+	}
+	return errs.Wrap(err)
+}
+
+//filterReader is io.Reader which maps or drops each byte in stream.
+type filterReader struct {
+	r       io.ByteReader
+	mapping func(byte) (byte, bool) //returns false if the byte is dropped
+}
+
+//Read method is implementation of io.Reader interface.
+func (fr *filterReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		c, err := fr.r.ReadByte()
+		if err != nil {
+			if n > 0 && errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, err
+		}
+		if c, ok := fr.mapping(c); ok {
+			p[n] = c
+			n++
+		}
+	}
+	return n, nil
+}
+
+//newlineMapping drops CR and LF characters.
+func newlineMapping(c byte) (byte, bool) {
+	return c, c != '\r' && c != '\n'
+}
+
+//crockfordMapping maps lower case letters and confusable letters (I, L, O) in Crockford's Base32, and drops hyphens.
+func crockfordMapping(c byte) (byte, bool) {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'I', 'L':
+		return '1', true
+	case 'O':
+		return '0', true
+	case '-':
+		return c, false
+	}
+	return newlineMapping(c)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,102 @@
+package basen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+func TestAlphabetList(t *testing.T) {
+	res := "base32|base32hex|crockford|base58|hex"
+	str := strings.Join(AlphabetList(), "|")
+	if str != res {
+		t.Errorf("AlphabetList() = \"%+v\", want \"%+v\".", str, res)
+	}
+	for _, name := range AlphabetList() {
+		if a, err := AlphabetOf(strings.ToUpper(name)); err != nil || a.String() != name {
+			t.Errorf("AlphabetOf(%s) = \"%+v\", %v.", name, a, err)
+		}
+	}
+	if _, err := AlphabetOf("foo"); !errs.Is(err, ecode.ErrInvalidAlphabet) {
+		t.Errorf("AlphabetOf(foo) error = \"%+v\", want \"%+v\".", err, ecode.ErrInvalidAlphabet)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	testCases := []struct {
+		alphabet Alphabet
+		raw      string
+		encoded  string
+	}{
+		{alphabet: Base32, raw: "foobar", encoded: "MZXW6YTBOI======"},
+		{alphabet: Base32Hex, raw: "foobar", encoded: "CPNMUOJ1E8======"},
+		{alphabet: Crockford, raw: "foobar", encoded: "CSQPYRK1E8"},
+		{alphabet: Base58, raw: "Hello World!", encoded: "2NEpo7TZRRrLZSi2U"},
+		{alphabet: Base58, raw: "\x00\x00\x01", encoded: "112"},
+		{alphabet: Base58, raw: "", encoded: ""},
+		{alphabet: Hex, raw: "foobar", encoded: "666f6f626172"},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if err := Encode(tc.alphabet, strings.NewReader(tc.raw), buf); err != nil {
+			t.Errorf("Encode(%v, %q) error = \"%+v\", want nil.", tc.alphabet, tc.raw, err)
+		} else if buf.String() != tc.encoded {
+			t.Errorf("Encode(%v, %q) = %q, want %q.", tc.alphabet, tc.raw, buf.String(), tc.encoded)
+		}
+		buf.Reset()
+		if err := Decode(tc.alphabet, strings.NewReader(tc.encoded+"\n"), buf); err != nil {
+			t.Errorf("Decode(%v, %q) error = \"%+v\", want nil.", tc.alphabet, tc.encoded, err)
+		} else if buf.String() != tc.raw {
+			t.Errorf("Decode(%v, %q) = %q, want %q.", tc.alphabet, tc.encoded, buf.String(), tc.raw)
+		}
+	}
+}
+
+func TestDecodeCrockford(t *testing.T) {
+	for _, s := range []string{"csqpyrk1e8", "CSQP-YRKI-E8", "CSQPYRKLE8"} {
+		buf := &bytes.Buffer{}
+		if err := Decode(Crockford, strings.NewReader(s), buf); err != nil {
+			t.Errorf("Decode(%q) error = \"%+v\", want nil.", s, err)
+		} else if buf.String() != "foobar" {
+			t.Errorf("Decode(%q) = %q, want %q.", s, buf.String(), "foobar")
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	testCases := []struct {
+		alphabet Alphabet
+		encoded  string
+		err      error
+	}{
+		{alphabet: Base32, encoded: "MZXW6YT!", err: ecode.ErrInvalidEncodedText},
+		{alphabet: Crockford, encoded: "CSQPYRKUE8", err: ecode.ErrInvalidEncodedText},
+		{alphabet: Base58, encoded: "2NEpo0", err: ecode.ErrInvalidEncodedText},
+		{alphabet: Hex, encoded: "66zz", err: ecode.ErrInvalidEncodedText},
+		{alphabet: Hex, encoded: "666", err: ecode.ErrInvalidEncodedText},
+		{alphabet: Alphabet(-1), encoded: "", err: ecode.ErrInvalidAlphabet},
+	}
+	for _, tc := range testCases {
+		if err := Decode(tc.alphabet, strings.NewReader(tc.encoded), &bytes.Buffer{}); !errs.Is(err, tc.err) {
+			t.Errorf("Decode(%v, %q) error = \"%+v\", want \"%+v\".", tc.alphabet, tc.encoded, err, tc.err)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
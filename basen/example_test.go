@@ -0,0 +1,48 @@
+package basen_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/goark/gnkf/basen"
+)
+
+func ExampleEncode() {
+	input := strings.NewReader("Hello World\n")
+	output := &bytes.Buffer{}
+	if err := basen.Encode(basen.Base58, input, output); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(output.String())
+	//Output:
+	//2NEpo7TZRRrLZSi25
+}
+
+func ExampleDecode() {
+	input := strings.NewReader("JBSWY3DPEBLW64TMMQFA====")
+	output := &bytes.Buffer{}
+	if err := basen.Decode(basen.Base32, input, output); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(output.String())
+	//Output:
+	//Hello World
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
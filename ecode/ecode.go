@@ -10,8 +10,21 @@ var (
 	ErrInvalidUTF8Text      = errors.New("invalid UTF-8 text")
 	ErrNotSuppotEncoding    = errors.New("not support IANA encoding name")
 	ErrInvalidEncoding      = errors.New("text is invalid encoding")
+	ErrInvalidFallback      = errors.New("invalid fallback mode")
+	ErrInvalidProfile       = errors.New("invalid mapping profile")
+	ErrInvalidStrategy      = errors.New("invalid guess strategy")
+	ErrInvalidReplacement   = errors.New("replacement string is unencodable")
+	ErrBinaryData           = errors.New("binary data (not text)")
+	ErrExclusiveOptions     = errors.New("exclusive options are specified together")
+	ErrInvalidBomForm       = errors.New("invalid BOM form")
+	ErrMismatchBom          = errors.New("BOM of other encoding form in the text")
+	ErrInvalidAlphabet      = errors.New("invalid alphabet for baseN encoding")
+	ErrInvalidEncodedText   = errors.New("invalid baseN encoded text")
+	ErrInvalidPEMBlock      = errors.New("invalid PEM block")
+	ErrNoPEMBlock           = errors.New("no PEM block")
 	ErrInvalidNormForm      = errors.New("invalid Unicode normalization form")
 	ErrInvalidNewlineForm   = errors.New("invalid newline form")
+	ErrMixedNewlines        = errors.New("mixed newlines in the text")
 	ErrInvalidWidthForm     = errors.New("invalid width form")
 	ErrInvalidKanaForm      = errors.New("invalid kana form")
 	ErrInvalidHashAlg       = errors.New("not support hash algorithm")
@@ -20,7 +33,7 @@ var (
 	ErrInvalidChekerFormat  = errors.New("invalid checker format")
 )
 
-/* Copyright 2020-2021 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
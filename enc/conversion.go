@@ -11,31 +11,53 @@ import (
 
 //Convert function converts character encoding text stream.
 func Convert(toIanaName string, writer io.Writer, fromIanaName string, txt io.Reader) error {
+	_, err := ConvertWithOptions(toIanaName, writer, fromIanaName, txt, nil)
+	return err
+}
+
+//ConvertWithOptions function converts character encoding text stream with options.
+//It returns count of substituted characters by fallback mode and invalid byte sequences in lenient mode.
+func ConvertWithOptions(toIanaName string, writer io.Writer, fromIanaName string, txt io.Reader, opts *Options) (int, error) {
 	encoder, err := Encoding(toIanaName)
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("toIanaName", toIanaName))
+		return 0, errs.Wrap(err, errs.WithContext("toIanaName", toIanaName))
 	}
 	decoder, err := Encoding(fromIanaName)
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("fromIanaName", fromIanaName))
+		return 0, errs.Wrap(err, errs.WithContext("fromIanaName", fromIanaName))
 	}
+	encoder, decoder = opts.withProfile(opts.withFoldKana(encoder)), opts.withProfile(decoder)
 	if encoder == unicode.UTF8 {
-		return decode(decoder, writer, txt)
+		return decode(decoder, writer, txt, opts)
 	}
 	if decoder == unicode.UTF8 {
-		return encode(encoder, writer, txt)
+		return encode(encoder, writer, txt, opts)
 	}
-	return convert(encoder, decoder, writer, txt)
+	return convert(encoder, decoder, writer, txt, opts)
 }
 
-func convert(encoder, decoder encoding.Encoding, writer io.Writer, txt io.Reader) error {
-	if encoder == decoder {
-		return notConvert(writer, txt)
+func convert(encoder, decoder encoding.Encoding, writer io.Writer, txt io.Reader, opts *Options) (int, error) {
+	if encoder == decoder && !opts.lenient() {
+		return 0, notConvert(writer, txt)
 	}
-	if _, err := io.Copy(encoder.NewEncoder().Writer(writer), decoder.NewDecoder().Reader(txt)); err != nil {
-		return errs.Wrap(ecode.ErrInvalidEncoding, errs.WithCause(err))
+	t := newDecodeTransformer(decoder, newEncodeTransformer(encoder.NewEncoder(), opts), opts)
+	if t.lenient && opts.fallback() == FallbackNone && !encodable(encoder, t.repl) {
+		//reject unencodable replacement before output
+		if opts.hasReplacement() {
+			return 0, errs.Wrap(ecode.ErrInvalidReplacement, errs.WithContext("replacement", opts.replacement()))
+		}
+		t.repl = []byte(asciiReplacement)
 	}
-	return nil
+	if err := copyTransform(writer, t, txt); err != nil {
+		return t.count + t.encoder.count, errs.Wrap(wrapInvalid(err), errs.WithContext("fallback", opts.fallback().String()))
+	}
+	return t.count + t.encoder.count, nil
+}
+
+//encodable returns true if the text can be encoded by the encoding.
+func encodable(e encoding.Encoding, b []byte) bool {
+	_, err := e.NewEncoder().Bytes(b)
+	return err == nil
 }
 
 func notConvert(writer io.Writer, txt io.Reader) error {
@@ -45,7 +67,7 @@ func notConvert(writer io.Writer, txt io.Reader) error {
 	return nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -4,31 +4,39 @@ import (
 	"io"
 
 	"github.com/goark/errs"
-	"github.com/goark/gnkf/ecode"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/unicode"
 )
 
 //Decode converts from UTF-8 encodeing text.
+//If the text includes invalid byte sequence, it returns ErrInvalidEncoding error with the position.
 func Decode(writer io.Writer, ianaName string, txt io.Reader) error {
+	_, err := DecodeWithOptions(writer, ianaName, txt, nil)
+	return err
+}
+
+//DecodeWithOptions converts from UTF-8 encodeing text with options.
+//It returns count of substituted invalid byte sequences in lenient mode.
+func DecodeWithOptions(writer io.Writer, ianaName string, txt io.Reader, opts *Options) (int, error) {
 	decoder, err := Encoding(ianaName)
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("ianaName", ianaName))
+		return 0, errs.Wrap(err, errs.WithContext("ianaName", ianaName))
 	}
-	return decode(decoder, writer, txt)
+	return decode(opts.withProfile(decoder), writer, txt, opts)
 }
 
-func decode(decoder encoding.Encoding, writer io.Writer, txt io.Reader) error {
-	if decoder == unicode.UTF8 {
-		return notConvert(writer, txt)
+func decode(decoder encoding.Encoding, writer io.Writer, txt io.Reader, opts *Options) (int, error) {
+	if decoder == unicode.UTF8 && !opts.lenient() {
+		return 0, notConvert(writer, txt)
 	}
-	if _, err := io.Copy(writer, decoder.NewDecoder().Reader(txt)); err != nil {
-		return errs.Wrap(ecode.ErrInvalidEncoding, errs.WithCause(err))
+	t := newDecodeTransformer(decoder, nil, opts)
+	if err := copyTransform(writer, t, txt); err != nil {
+		return t.count, wrapInvalid(err)
 	}
-	return nil
+	return t.count, nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -2,7 +2,9 @@ package enc
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/ecode"
@@ -93,7 +95,444 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
-/* Copyright 2020 Spiegel
+func TestEncodeWithOptions(t *testing.T) {
+	testCases := []struct {
+		inp, out []byte
+		ianaName string
+		opts     *Options
+		count    int
+		err      error
+	}{
+		{inp: []byte("Hello 🍣!"), out: []byte{}, ianaName: "shift_jis", opts: nil, count: 0, err: ecode.ErrInvalidEncoding},
+		{inp: []byte("Hello 🍣!"), out: []byte{}, ianaName: "shift_jis", opts: &Options{Fallback: FallbackNone}, count: 0, err: ecode.ErrInvalidEncoding},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello !"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackSkip}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello ?!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackQuestion}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte{0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x20, 0x81, 0xac, 0x21}, ianaName: "shift_jis", opts: &Options{Fallback: FallbackGeta}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello &#127843;!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackHTML}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello &#x1F363;!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackXML}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello \\uD83C\\uDF63!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackJava}, count: 1, err: nil},
+		{inp: []byte("Hello ©🍣!"), out: []byte("Hello \\u00A9\\uD83C\\uDF63!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackJava}, count: 2, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello **!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackSubchar, Subchar: "**"}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte("Hello ?!"), ianaName: "shift_jis", opts: &Options{Fallback: FallbackSubchar}, count: 1, err: nil},
+		{inp: []byte("Hello 🍣!"), out: []byte{}, ianaName: "us-ascii", opts: &Options{Fallback: FallbackGeta}, count: 0, err: ecode.ErrInvalidEncoding},
+		{inp: []byte("こ🍣ん"), out: []byte("\x1b$B$3\x1b(B&#127843;\x1b$B$s\x1b(B"), ianaName: "iso-2022-jp", opts: &Options{Fallback: FallbackHTML}, count: 1, err: nil},
+		{inp: []byte("こ🍣ん"), out: []byte("\x1b$B$3\x1b(B\x1b$B\".$s\x1b(B"), ianaName: "iso-2022-jp", opts: &Options{Fallback: FallbackGeta}, count: 1, err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if count, err := EncodeWithOptions(tc.ianaName, buf, bytes.NewReader(tc.inp), tc.opts); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("EncodeWithOptions() error = \"%+v\", want \"%+v\".", err, tc.err)
+			}
+		} else if count != tc.count {
+			t.Errorf("EncodeWithOptions(%s) count = %v, want %v.", tc.ianaName, count, tc.count)
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("EncodeWithOptions(%s) = %q, want %q.", tc.ianaName, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestConvertWithOptions(t *testing.T) {
+	testCases := []struct {
+		inp, out []byte
+		from, to string
+		opts     *Options
+		count    int
+		err      error
+	}{
+		{inp: []byte{0x8f, 0xb0, 0xa1, 0xa4, 0xa2}, out: []byte{}, from: "euc-jp", to: "shift_jis", opts: nil, count: 0, err: ecode.ErrInvalidEncoding},
+		{inp: []byte{0x8f, 0xb0, 0xa1, 0xa4, 0xa2}, out: []byte{0x81, 0xac, 0x82, 0xa0}, from: "euc-jp", to: "shift_jis", opts: &Options{Fallback: FallbackGeta}, count: 1, err: nil},
+		{inp: []byte{0x8f, 0xb0, 0xa1, 0xa4, 0xa2}, out: []byte("&#x4E02;\x82\xa0"), from: "euc-jp", to: "shift_jis", opts: &Options{Fallback: FallbackXML}, count: 1, err: nil},
+		{inp: []byte{0xa4, 0xa2, 0xff, 0xa4, 0xa2}, out: []byte{0x82, 0xa0, 0x3f, 0x82, 0xa0}, from: "euc-jp", to: "shift_jis", opts: &Options{Lenient: true}, count: 1, err: nil},
+		{inp: []byte{0xa4, 0xa2, 0xff, 0xa4, 0xa2}, out: []byte{}, from: "euc-jp", to: "shift_jis", opts: &Options{Lenient: true, Replacement: "\U0001F363"}, count: 0, err: ecode.ErrInvalidReplacement},
+		{inp: []byte("abc\xffdef"), out: []byte("abc?def"), from: "utf-8", to: "shift_jis", opts: &Options{Lenient: true}, count: 1, err: nil},
+		{inp: []byte("あ\xffい"), out: []byte{0x82, 0xa0, 0x81, 0xac, 0x82, 0xa2}, from: "utf-8", to: "shift_jis", opts: &Options{Lenient: true, Replacement: "〓"}, count: 1, err: nil},
+		{inp: []byte{0xa4, 0xa2, 0xff, 0xa4, 0xa2}, out: []byte{0x82, 0xa0, 0x3f, 0x82, 0xa0}, from: "euc-jp", to: "shift_jis", opts: &Options{Lenient: true, Replacement: "?"}, count: 1, err: nil},
+		{inp: []byte{0xa4, 0xa2, 0xff, 0x8f, 0xb0, 0xa1}, out: []byte{0x82, 0xa0, 0x81, 0xac, 0x81, 0xac}, from: "euc-jp", to: "shift_jis", opts: &Options{Lenient: true, Fallback: FallbackGeta}, count: 2, err: nil},
+		{inp: []byte{0x82, 0xa0, 0xff}, out: []byte{0x82, 0xa0, 0x3f}, from: "shift_jis", to: "shift_jis", opts: &Options{Lenient: true, Replacement: "?"}, count: 1, err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if count, err := ConvertWithOptions(tc.to, buf, tc.from, bytes.NewReader(tc.inp), tc.opts); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("ConvertWithOptions() error = \"%+v\", want \"%+v\".", err, tc.err)
+			}
+		} else if count != tc.count {
+			t.Errorf("ConvertWithOptions(%s -> %s) count = %v, want %v.", tc.from, tc.to, count, tc.count)
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("ConvertWithOptions(%s -> %s) = %q, want %q.", tc.from, tc.to, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestFallbackOf(t *testing.T) {
+	testCases := []struct {
+		name string
+		fb   Fallback
+		err  error
+	}{
+		{name: "none", fb: FallbackNone, err: nil},
+		{name: "SKIP", fb: FallbackSkip, err: nil},
+		{name: "Geta", fb: FallbackGeta, err: nil},
+		{name: "subchar", fb: FallbackSubchar, err: nil},
+		{name: "foo", fb: FallbackNone, err: ecode.ErrInvalidFallback},
+	}
+	for _, tc := range testCases {
+		fb, err := FallbackOf(tc.name)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("FallbackOf() error = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+		if fb != tc.fb {
+			t.Errorf("FallbackOf(%s) = %v, want %v.", tc.name, fb, tc.fb)
+		}
+	}
+	res := "none|skip|question|geta|html|xml|java|subchar"
+	if str := strings.Join(FallbackList(), "|"); str != res {
+		t.Errorf("FallbackList() = \"%+v\", want \"%+v\".", str, res)
+	}
+}
+
+func TestFindInvalid(t *testing.T) {
+	longSJIS := bytes.Repeat([]byte{0x82, 0xa0}, 3000)
+	testCases := []struct {
+		inp      []byte
+		from, to string
+		res      string
+		err      error
+	}{
+		{inp: textSJIS, from: "shift_jis", to: "utf-8", res: "", err: nil},
+		{inp: textUTF8, from: "utf-8", to: "euc-jp", res: "", err: nil},
+		{inp: []byte("\x82\xa0\n\xff\x82\xa2\x82\x41a"), from: "shift_jis", to: "utf-8", res: "offset 3 (line 2, column 1): invalid byte sequence [0xff]|offset 6 (line 2, column 3): invalid byte sequence [0x82, 0x41]", err: nil},
+		{inp: []byte("あ\n🍣い\xffz"), from: "utf-8", to: "shift_jis", res: "offset 4 (line 2, column 1): unencodable character U+1F363 [0xf0, 0x9f, 0x8d, 0xa3]|offset 11 (line 2, column 3): invalid byte sequence [0xff]", err: nil},
+		{inp: []byte{0xa4, 0xa2, 0x0a, 0x8f, 0xb0, 0xa1, 0xa4, 0xa2}, from: "euc-jp", to: "shift_jis", res: "offset 3 (line 2, column 1): unencodable character U+4E02 [0x8f, 0xb0, 0xa1]", err: nil},
+		{inp: []byte{0xfd, 0xff, 0x00, 0xd8, 0x0a, 0x00}, from: "utf-16le", to: "utf-8", res: "offset 2 (line 1, column 2): invalid byte sequence [0x00, 0xd8, 0x0a, 0x00]", err: nil},
+		{inp: append(append([]byte{}, longSJIS...), 0xff, 0x82), from: "shift_jis", to: "euc-jp", res: "offset 6000 (line 1, column 3001): invalid byte sequence [0xff]|offset 6001 (line 1, column 3002): invalid byte sequence [0x82]", err: nil},
+		{inp: []byte(strings.Repeat("あ", 3000) + "\n🍣"), from: "utf-8", to: "shift_jis", res: "offset 9001 (line 2, column 1): unencodable character U+1F363 [0xf0, 0x9f, 0x8d, 0xa3]", err: nil},
+		{inp: []byte("a\x82\nb\x82"), from: "shift_jis", to: "utf-8", res: "offset 1 (line 1, column 2): invalid byte sequence [0x82]|offset 4 (line 2, column 2): invalid byte sequence [0x82]", err: nil},
+		{inp: []byte("\xa4\n\xa4\xa2"), from: "euc-jp", to: "utf-8", res: "offset 0 (line 1, column 1): invalid byte sequence [0xa4]", err: nil},
+		{inp: []byte("\xe3\x81\nz\xe3\x81"), from: "utf-8", to: "shift_jis", res: "offset 0 (line 1, column 1): invalid byte sequence [0xe3, 0x81]|offset 4 (line 2, column 2): invalid byte sequence [0xe3, 0x81]", err: nil},
+		{inp: textUTF8, from: "foo", to: "utf-8", res: "", err: ecode.ErrNotSuppotEncoding},
+		{inp: textUTF8, from: "utf-8", to: "bar", res: "", err: ecode.ErrNotSuppotEncoding},
+	}
+	for _, tc := range testCases {
+		list, err := FindInvalid(tc.to, tc.from, bytes.NewReader(tc.inp))
+		if !errs.Is(err, tc.err) {
+			t.Errorf("FindInvalid() error = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+		ss := []string{}
+		for _, iv := range list {
+			ss = append(ss, iv.String())
+		}
+		if str := strings.Join(ss, "|"); str != tc.res {
+			t.Errorf("FindInvalid(%s -> %s) = \"%v\", want \"%v\".", tc.from, tc.to, str, tc.res)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	testCases := []struct {
+		inp      []byte
+		ianaName string
+		out      []byte
+	}{
+		{inp: []byte{0x82, 0xa0, 0x0a, 0xff, 0x82, 0xa2}, ianaName: "shift_jis", out: []byte("あ\n")},
+		{inp: []byte{0xa4, 0xa2, 0x0a, 0xa4}, ianaName: "euc-jp", out: []byte("あ\n")},
+		{inp: []byte{0x1b, 0x24, 0x42, 0x24, 0x22, 0x7f}, ianaName: "iso-2022-jp", out: []byte("あ")},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if err := Decode(buf, tc.ianaName, bytes.NewReader(tc.inp)); !errs.Is(err, ecode.ErrInvalidEncoding) {
+			t.Errorf("Decode() error = \"%+v\", want \"%+v\".", err, ecode.ErrInvalidEncoding)
+		}
+		if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("Decode(%s) = %q, want %q.", tc.ianaName, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestDecodeWithOptions(t *testing.T) {
+	longJIS := append(append([]byte("\x1b$B"), bytes.Repeat([]byte{0x24, 0x22}, 3000)...), 0xff, 0x24, 0x24, 0x1b, 0x28, 0x42, 0x61)
+	longUTF16 := append(append([]byte{0xfe, 0xff}, bytes.Repeat([]byte{0x30, 0x42}, 3000)...), 0x30, 0x44, 0xdc, 0x00, 0x30, 0x46, 0x00)
+	testCases := []struct {
+		inp, out []byte
+		ianaName string
+		opts     *Options
+		count    int
+		err      error
+	}{
+		{inp: []byte{0x82, 0xa0, 0xff, 0x82, 0xa2}, out: []byte{}, ianaName: "shift_jis", opts: nil, count: 0, err: ecode.ErrInvalidEncoding},
+		{inp: []byte{0x82, 0xa0, 0xff, 0x82, 0xa2}, out: []byte("あ\uFFFDい"), ianaName: "shift_jis", opts: &Options{Lenient: true}, count: 1, err: nil},
+		{inp: []byte{0x82, 0xa0, 0xff, 0x82, 0xa2, 0x82}, out: []byte("あ?い?"), ianaName: "shift_jis", opts: &Options{Lenient: true, Replacement: "?"}, count: 2, err: nil},
+		{inp: []byte{0xa4, 0xa2, 0x0a, 0xa4}, out: []byte("あ\n[?]"), ianaName: "euc-jp", opts: &Options{Lenient: true, Replacement: "[?]"}, count: 1, err: nil},
+		{inp: []byte("a\xffb\xef\xbf\xbd\xc3"), out: []byte("a\xffb\xef\xbf\xbd\xc3"), ianaName: "utf-8", opts: nil, count: 0, err: nil},
+		{inp: []byte("a\xffb\xef\xbf\xbd\xc3"), out: []byte("a\uFFFDb\uFFFD\uFFFD"), ianaName: "utf-8", opts: &Options{Lenient: true}, count: 2, err: nil},
+		{inp: longJIS, out: []byte(strings.Repeat("あ", 3000) + "\uFFFDいa"), ianaName: "iso-2022-jp", opts: &Options{Lenient: true}, count: 1, err: nil},
+		{inp: longJIS, out: []byte(strings.Repeat("あ", 3000) + "\uFFFDいa"), ianaName: "cp50221", opts: &Options{Lenient: true}, count: 1, err: nil},
+		{inp: longUTF16, out: []byte(strings.Repeat("あ", 3000) + "い\uFFFDう\uFFFD"), ianaName: "utf-16", opts: &Options{Lenient: true}, count: 2, err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if count, err := DecodeWithOptions(buf, tc.ianaName, bytes.NewReader(tc.inp), tc.opts); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("DecodeWithOptions() error = \"%+v\", want \"%+v\".", err, tc.err)
+			}
+		} else if count != tc.count {
+			t.Errorf("DecodeWithOptions(%s) count = %v, want %v.", tc.ianaName, count, tc.count)
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("DecodeWithOptions(%s) = %q, want %q.", tc.ianaName, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestProfileOf(t *testing.T) {
+	testCases := []struct {
+		name    string
+		profile Profile
+		err     error
+	}{
+		{name: "none", profile: ProfileNone, err: nil},
+		{name: "JIS-strict", profile: ProfileJIS, err: nil},
+		{name: "cp932", profile: ProfileCP932, err: nil},
+		{name: "eucJP-ms", profile: ProfileEUCJPMS, err: nil},
+		{name: "CP51932", profile: ProfileCP51932, err: nil},
+		{name: "foo", profile: ProfileNone, err: ecode.ErrInvalidProfile},
+	}
+	for _, tc := range testCases {
+		p, err := ProfileOf(tc.name)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("ProfileOf(%v) error = \"%+v\", want \"%+v\".", tc.name, err, tc.err)
+		}
+		if p != tc.profile {
+			t.Errorf("ProfileOf(%v) = \"%v\", want \"%v\".", tc.name, p, tc.profile)
+		}
+	}
+	if len(ProfileList()) != len(profileNamesMap) {
+		t.Errorf("ProfileList() = %v, want %v items.", ProfileList(), len(profileNamesMap))
+	}
+}
+
+func TestConvertWithProfile(t *testing.T) {
+	troubleSJIS := []byte{0x81, 0x5c, 0x81, 0x60, 0x81, 0x61, 0x81, 0x7c, 0x81, 0x91, 0x81, 0x92, 0x81, 0xca}
+	troubleEUC := []byte{0xa1, 0xbd, 0xa1, 0xc1, 0xa1, 0xc2, 0xa1, 0xdd, 0xa1, 0xf1, 0xa1, 0xf2, 0xa2, 0xcc}
+	testCases := []struct {
+		inp, out []byte
+		to, from string
+		profile  Profile
+		fallback Fallback
+		err      error
+	}{
+		{inp: troubleSJIS, out: []byte("―～∥－￠￡￢"), to: "utf-8", from: "shift_jis", profile: ProfileNone, err: nil},
+		{inp: troubleSJIS, out: []byte("―～∥－￠￡￢"), to: "utf-8", from: "windows-31j", profile: ProfileNone, err: nil},
+		{inp: troubleSJIS, out: []byte("—〜‖−¢£¬"), to: "utf-8", from: "shift_jis", profile: ProfileJIS, err: nil},
+		{inp: troubleSJIS, out: []byte("―～∥－￠￡￢"), to: "utf-8", from: "cp932", profile: ProfileJIS, err: nil},
+		{inp: troubleEUC, out: []byte("―〜‖−¢£¬"), to: "utf-8", from: "eucJP-ms", profile: ProfileNone, err: nil},
+		{inp: troubleEUC, out: []byte("―～∥－￠￡￢"), to: "utf-8", from: "cp51932", profile: ProfileNone, err: nil},
+		{inp: troubleEUC, out: []byte("―〜‖−¢£¬"), to: "utf-8", from: "euc-jp", profile: ProfileEUCJPMS, err: nil},
+		{inp: []byte("—〜‖−¢£¬"), out: troubleSJIS, to: "shift_jis", from: "utf-8", profile: ProfileJIS, err: nil},
+		{inp: []byte("―〜‖−¢£¬"), out: troubleEUC, to: "eucjp-ms", from: "utf-8", profile: ProfileNone, err: nil},
+		{inp: []byte("―～∥－￠￡￢"), out: troubleEUC, to: "eucjp-ms", from: "utf-8", profile: ProfileNone, err: nil},
+		{inp: []byte("—〜‖−¢£¬"), out: troubleSJIS, to: "shift_jis", from: "utf-8", profile: ProfileCP932, err: nil},
+		{inp: []byte("―～∥－￠￡￢"), out: troubleSJIS, to: "shift_jis", from: "utf-8", profile: ProfileJIS, err: nil},
+		{inp: []byte("—〜‖−¢£¬"), out: troubleEUC, to: "euc-jp", from: "utf-8", profile: ProfileCP51932, err: nil},
+		{inp: []byte("―～∥－￠￡￢"), out: troubleEUC, to: "euc-jp", from: "utf-8", profile: ProfileEUCJPMS, err: nil},
+		{inp: []byte("〜"), out: []byte{0xa1, 0xc1}, to: "cp51932", from: "utf-8", profile: ProfileNone, err: nil},
+		{inp: []byte("〜"), out: []byte{}, to: "shift_jis", from: "utf-8", profile: ProfileNone, err: ecode.ErrInvalidEncoding},
+		{inp: []byte("寿司〜🍣"), out: []byte{0x8e, 0xf5, 0x8e, 0x69, 0x81, 0x60, 0x3f}, to: "shift_jis", from: "utf-8", profile: ProfileJIS, fallback: FallbackQuestion, err: nil},
+		{inp: troubleSJIS, out: troubleEUC, to: "eucjp-ms", from: "cp932", profile: ProfileNone, err: nil},
+		{inp: []byte{0x1b, 0x24, 0x42, 0x21, 0x41, 0x1b, 0x28, 0x42}, out: []byte("〜"), to: "utf-8", from: "iso-2022-jp", profile: ProfileJIS, err: nil},
+		{inp: []byte("〜"), out: []byte{0x1b, 0x24, 0x42, 0x21, 0x41, 0x1b, 0x28, 0x42}, to: "iso-2022-jp", from: "utf-8", profile: ProfileJIS, err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if _, err := ConvertWithOptions(tc.to, buf, tc.from, bytes.NewReader(tc.inp), &Options{Fallback: tc.fallback, Profile: tc.profile}); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("ConvertWithOptions(%s, %s, %v) error = \"%+v\", want \"%+v\".", tc.to, tc.from, tc.profile, err, tc.err)
+			}
+		} else if tc.err != nil {
+			t.Errorf("ConvertWithOptions(%s, %s, %v) error = nil, want \"%+v\".", tc.to, tc.from, tc.profile, tc.err)
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("ConvertWithOptions(%s, %s, %v) = \"% x\", want \"% x\".", tc.to, tc.from, tc.profile, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestRemapString(t *testing.T) {
+	testCases := []struct {
+		inp, out string
+		to, from Profile
+	}{
+		{inp: "―〜‖−¢£¬", out: "―～∥－￠￡￢", to: ProfileCP932, from: ProfileEUCJPMS},
+		{inp: "―～∥－￠￡￢", out: "—〜‖−¢£¬", to: ProfileJIS, from: ProfileCP932},
+		{inp: "—〜‖−¢£¬", out: "―〜‖−¢£¬", to: ProfileEUCJPMS, from: ProfileJIS},
+		{inp: "〜＼~\\", out: "〜＼~\\", to: ProfileCP932, from: ProfileCP932},
+		{inp: "Hello, 世界〜", out: "Hello, 世界～", to: ProfileCP51932, from: ProfileJIS},
+	}
+	for _, tc := range testCases {
+		if s := RemapString(tc.to, tc.from, tc.inp); s != tc.out {
+			t.Errorf("RemapString(%v, %v, %q) = %q, want %q.", tc.to, tc.from, tc.inp, s, tc.out)
+		}
+		buf := &bytes.Buffer{}
+		if err := Remap(tc.to, buf, tc.from, strings.NewReader(tc.inp)); err != nil {
+			t.Errorf("Remap() error = \"%+v\", want nil.", err)
+		} else if buf.String() != tc.out {
+			t.Errorf("Remap(%v, %v, %q) = %q, want %q.", tc.to, tc.from, tc.inp, buf.String(), tc.out)
+		}
+	}
+}
+
+func TestISO2022JPVariants(t *testing.T) {
+	testCases := []struct {
+		txt      string
+		out      []byte
+		ianaName string
+		err      error
+	}{
+		{txt: "ｱｶﾞﾜﾞ①", out: []byte("\x1b$B%\"%,%o!+-!\x1b(B"), ianaName: "CP50220", err: nil},
+		{txt: "ｱｶﾞ①", out: []byte("\x1b(I16^\x1b$B-!\x1b(B"), ianaName: "CP50221", err: nil},
+		{txt: "ｱｶﾞ\nA", out: []byte("\x0e16^\x0f\nA"), ianaName: "CP50222", err: nil},
+		{txt: "あ丂¥", out: []byte("\x1b$B$\"\x1b$(D0!\x1b(J\\\x1b(B"), ianaName: "ISO-2022-JP-1", err: nil},
+		{txt: "ｱ", out: nil, ianaName: "ISO-2022-JP-1", err: ecode.ErrInvalidEncoding},
+		{txt: "①", out: nil, ianaName: "ISO-2022-JP-1", err: ecode.ErrInvalidEncoding},
+		{txt: "〜か゚①丂", out: []byte("\x1b$B!A\x1b$(O$w-!\x1b$(P!\"\x1b(B"), ianaName: "ISO-2022-JP-3", err: nil},
+		{txt: "俱", out: nil, ianaName: "ISO-2022-JP-3", err: ecode.ErrInvalidEncoding},
+		{txt: "〜か゚か①俱", out: []byte("\x1b$B!A\x1b$(Q$w\x1b$B$+\x1b$(Q-!.!\x1b(B"), ianaName: "ISO-2022-JP-2004", err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if err := Encode(tc.ianaName, buf, strings.NewReader(tc.txt)); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("Encode(%s) error = \"%+v\", want \"%+v\".", tc.ianaName, err, tc.err)
+			}
+			continue
+		} else if tc.err != nil {
+			t.Errorf("Encode(%s) error = nil, want \"%+v\".", tc.ianaName, tc.err)
+			continue
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("Encode(%s) = %q, want %q.", tc.ianaName, buf.Bytes(), tc.out)
+		}
+		if tc.ianaName == "CP50220" {
+			continue
+		}
+		buf.Reset()
+		if err := Decode(buf, tc.ianaName, iotest.OneByteReader(bytes.NewReader(tc.out))); err != nil {
+			t.Errorf("Decode(%s) error = \"%+v\", want nil.", tc.ianaName, err)
+		} else if buf.String() != tc.txt {
+			t.Errorf("Decode(%s) = %q, want %q.", tc.ianaName, buf.String(), tc.txt)
+		}
+	}
+}
+
+func TestJISX0213Encodings(t *testing.T) {
+	testCases := []struct {
+		txt      string
+		out      []byte
+		ianaName string
+		err      error
+	}{
+		{txt: "か゚①丂ｱ\\", out: []byte("\x82\xf5\x87\x40\xf0\x41\xb1\\"), ianaName: "Shift_JIS-2004", err: nil},
+		{txt: "かき", out: []byte("\x82\xa9\x82\xab"), ianaName: "Shift_JIS-2004", err: nil},
+		{txt: "˥˩ｶﾞ殛𪚲", out: []byte("\x86\x86\xb6\xde\xf4\x9f\xfc\xf4"), ianaName: "Shift_JIS-2004", err: nil},
+		{txt: "¥", out: nil, ianaName: "Shift_JIS-2004", err: ecode.ErrInvalidEncoding},
+		{txt: "—", out: []byte("\x81\x5c"), ianaName: "Shift_JIS-2004", err: nil},
+		{txt: "か゚①丂ｱ\\", out: []byte("\xa4\xf7\xad\xa1\x8f\xa1\xa2\x8e\xb1\\"), ianaName: "EUC-JIS-2004", err: nil},
+		{txt: "かき", out: []byte("\xa4\xab\xa4\xad"), ianaName: "EUC-JIS-2004", err: nil},
+		{txt: "˥˩ｶﾞ殛𪚲", out: []byte("\xab\xe6\x8e\xb6\x8e\xde\x8f\xee\xa1\x8f\xfe\xf6"), ianaName: "EUC-JIS-2004", err: nil},
+		{txt: "丟", out: nil, ianaName: "EUC-JIS-2004", err: ecode.ErrInvalidEncoding},
+		{txt: "—", out: []byte("\xa1\xbd"), ianaName: "EUC-JIS-2004", err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if err := Encode(tc.ianaName, buf, iotest.OneByteReader(strings.NewReader(tc.txt))); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("Encode(%s) error = \"%+v\", want \"%+v\".", tc.ianaName, err, tc.err)
+			}
+			continue
+		} else if tc.err != nil {
+			t.Errorf("Encode(%s) error = nil, want \"%+v\".", tc.ianaName, tc.err)
+			continue
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("Encode(%s) = %q, want %q.", tc.ianaName, buf.Bytes(), tc.out)
+		}
+		buf.Reset()
+		if err := Decode(buf, tc.ianaName, iotest.OneByteReader(bytes.NewReader(tc.out))); err != nil {
+			t.Errorf("Decode(%s) error = \"%+v\", want nil.", tc.ianaName, err)
+		} else if buf.String() != tc.txt {
+			t.Errorf("Decode(%s) = %q, want %q.", tc.ianaName, buf.String(), tc.txt)
+		}
+	}
+	//U+2015 (HORIZONTAL BAR) is encoded to 1-1-29 for compatibility with CP932
+	for name, out := range map[string][]byte{"Shift_JIS-2004": []byte("\x81\x5c"), "EUC-JIS-2004": []byte("\xa1\xbd"), "ISO-2022-JP-2004": []byte("\x1b$B!=\x1b(B")} {
+		buf := &bytes.Buffer{}
+		if err := Encode(name, buf, strings.NewReader("―")); err != nil {
+			t.Errorf("Encode(%s) error = \"%+v\", want nil.", name, err)
+		} else if !bytes.Equal(buf.Bytes(), out) {
+			t.Errorf("Encode(%s) = %q, want %q.", name, buf.Bytes(), out)
+		}
+	}
+}
+
+func TestConvertFoldKana(t *testing.T) {
+	testCases := []struct {
+		inp, out []byte
+		to, from string
+		foldKana bool
+		err      error
+	}{
+		{inp: []byte("ｱｶﾞ"), out: []byte("\x1b(I16^\x1b(B"), to: "ISO-2022-JP", from: "UTF-8", foldKana: false, err: nil},
+		{inp: []byte("ｱｶﾞ"), out: []byte("\x1b$B%\"%,\x1b(B"), to: "ISO-2022-JP", from: "UTF-8", foldKana: true, err: nil},
+		{inp: []byte("ｱｶﾞ"), out: nil, to: "ISO-2022-JP-2004", from: "UTF-8", foldKana: false, err: ecode.ErrInvalidEncoding},
+		{inp: []byte("ｱｶﾞ"), out: []byte("\x1b$B%\"%,\x1b(B"), to: "ISO-2022-JP-2004", from: "UTF-8", foldKana: true, err: nil},
+		{inp: []byte{0xb1, 0xb6, 0xde, 0x82, 0xa9}, out: []byte("\x1b$B%\"%,$+\x1b(B"), to: "ISO-2022-JP-3", from: "Shift_JIS", foldKana: true, err: nil},
+		{inp: []byte{0xb1, 0xb6, 0xde}, out: []byte("\x1b(I16^\x1b(B"), to: "CP50221", from: "Shift_JIS", foldKana: true, err: nil},
+		{inp: []byte("\x1b(I16^\x1b(B"), out: []byte("\x1b$B%\"%,\x1b(B"), to: "CP50220", from: "CP50221", foldKana: false, err: nil},
+		{inp: []byte("\x0e16^\x0f"), out: []byte{0xb1, 0xb6, 0xde}, to: "Shift_JIS", from: "CP50222", foldKana: false, err: nil},
+		{inp: []byte("\x1b$(Q$w\x1b(B"), out: []byte("\x1b$(O$w\x1b(B"), to: "ISO-2022-JP-3", from: "ISO-2022-JP-2004", foldKana: false, err: nil},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if _, err := ConvertWithOptions(tc.to, buf, tc.from, iotest.OneByteReader(bytes.NewReader(tc.inp)), &Options{FoldKana: tc.foldKana}); err != nil {
+			if !errs.Is(err, tc.err) {
+				t.Errorf("ConvertWithOptions(%s, %s, %v) error = \"%+v\", want \"%+v\".", tc.to, tc.from, tc.foldKana, err, tc.err)
+			}
+		} else if tc.err != nil {
+			t.Errorf("ConvertWithOptions(%s, %s, %v) error = nil, want \"%+v\".", tc.to, tc.from, tc.foldKana, tc.err)
+		} else if !bytes.Equal(buf.Bytes(), tc.out) {
+			t.Errorf("ConvertWithOptions(%s, %s, %v) = %q, want %q.", tc.to, tc.from, tc.foldKana, buf.Bytes(), tc.out)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	names := map[string]bool{}
+	for _, info := range List() {
+		e, err := Encoding(info.Name)
+		if err != nil {
+			t.Errorf("Encoding(%s) error = \"%+v\", want nil.", info.Name, err)
+			continue
+		}
+		if !info.Encode || !info.Decode {
+			t.Errorf("List() %s = %+v, want encode and decode.", info.Name, info)
+		}
+		for _, alias := range append([]string{info.Name}, info.Aliases...) {
+			if names[strings.ToLower(alias)] {
+				t.Errorf("List() name %s is duplicated.", alias)
+			}
+			names[strings.ToLower(alias)] = true
+			if ea, err := Encoding(alias); err != nil {
+				t.Errorf("Encoding(%s) error = \"%+v\", want nil.", alias, err)
+			} else if ea != e {
+				t.Errorf("Encoding(%s) is not same as Encoding(%s).", alias, info.Name)
+			}
+		}
+	}
+	for _, name := range []string{"Shift_JIS", "EUC-JP", "UTF-8", "Shift_JIS-2004", "CP932"} {
+		if !names[strings.ToLower(name)] {
+			t.Errorf("List() does not contain %s.", name)
+		}
+	}
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -4,31 +4,42 @@ import (
 	"io"
 
 	"github.com/goark/errs"
-	"github.com/goark/gnkf/ecode"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/unicode"
 )
 
 //Encode converts UTF-8 from other character encoding text.
 func Encode(ianaName string, writer io.Writer, txt io.Reader) error {
+	_, err := EncodeWithOptions(ianaName, writer, txt, nil)
+	return err
+}
+
+//EncodeWithOptions converts UTF-8 from other character encoding text with options.
+//It returns count of substituted characters by fallback mode.
+//In lenient mode, invalid UTF-8 sequences in source text are replaced instead of error.
+func EncodeWithOptions(ianaName string, writer io.Writer, txt io.Reader, opts *Options) (int, error) {
 	encoder, err := Encoding(ianaName)
 	if err != nil {
-		return errs.Wrap(err, errs.WithContext("ianaName", ianaName))
+		return 0, errs.Wrap(err, errs.WithContext("ianaName", ianaName))
 	}
-	return encode(encoder, writer, txt)
+	return encode(opts.withProfile(opts.withFoldKana(encoder)), writer, txt, opts)
 }
 
-func encode(encoder encoding.Encoding, writer io.Writer, txt io.Reader) error {
+func encode(encoder encoding.Encoding, writer io.Writer, txt io.Reader, opts *Options) (int, error) {
+	if opts.lenient() {
+		return convert(encoder, unicode.UTF8, writer, txt, opts)
+	}
 	if encoder == unicode.UTF8 {
-		return notConvert(writer, txt)
+		return 0, notConvert(writer, txt)
 	}
-	if _, err := io.Copy(encoder.NewEncoder().Writer(writer), txt); err != nil {
-		return errs.Wrap(ecode.ErrInvalidEncoding, errs.WithCause(err))
+	t := newEncodeTransformer(encoder.NewEncoder(), opts)
+	if err := copyTransform(writer, t, txt); err != nil {
+		return t.count, errs.Wrap(wrapInvalid(err), errs.WithContext("fallback", opts.fallback().String()))
 	}
-	return nil
+	return t.count, nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -1,14 +1,50 @@
 package enc
 
 import (
+	"strings"
+
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/ecode"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/japanese"
 )
 
+//localEncodings is table of encodings implemented in this package (vendor mapping profiles, ISO-2022-JP variants, and so on).
+//The first name of each entry is the canonical name, and the others are aliases.
+var localEncodings = []struct {
+	names []string
+	e     encoding.Encoding
+}{
+	{names: []string{"CP932", "MS932", "Windows-31J", "csWindows31J"}, e: WithProfile(japanese.ShiftJIS, ProfileCP932)},
+	{names: []string{"eucJP-ms"}, e: WithProfile(japanese.EUCJP, ProfileEUCJPMS)},
+	{names: []string{"CP51932"}, e: WithProfile(japanese.EUCJP, ProfileCP51932)},
+	{names: []string{"CP50220"}, e: cp50220},
+	{names: []string{"CP50221"}, e: cp50221},
+	{names: []string{"CP50222"}, e: cp50222},
+	{names: []string{"ISO-2022-JP-1"}, e: iso2022JP1},
+	{names: []string{"ISO-2022-JP-3"}, e: iso2022JP3},
+	{names: []string{"ISO-2022-JP-2004"}, e: iso2022JP2004},
+	{names: []string{"Shift_JIS-2004", "Shift_JISX0213"}, e: shiftJIS2004},
+	{names: []string{"EUC-JIS-2004", "EUC-JISX0213"}, e: eucJIS2004},
+}
+
+//encodingsMap is table of lower-case names in localEncodings
+var encodingsMap = func() map[string]encoding.Encoding {
+	m := map[string]encoding.Encoding{}
+	for _, l := range localEncodings {
+		for _, name := range l.names {
+			m[strings.ToLower(name)] = l.e
+		}
+	}
+	return m
+}()
+
 //GetEncoding returns encoding.Encoding instance from MIME or IANA name
 func Encoding(ianaName string) (encoding.Encoding, error) {
+	if e, ok := encodingsMap[strings.ToLower(ianaName)]; ok {
+		return e, nil
+	}
 	e, err := ianaindex.IANA.Encoding(ianaName)
 	if err != nil {
 		e, err = ianaindex.MIME.Encoding(ianaName)
@@ -22,7 +58,7 @@ func Encoding(ianaName string) (encoding.Encoding, error) {
 	return e, nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
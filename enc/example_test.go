@@ -24,7 +24,27 @@ func ExampleConvert() {
 	//0x82, 0xb1, 0x82, 0xf1, 0x82, 0xc9, 0x82, 0xbf, 0x82, 0xcd, 0x81, 0x43, 0x90, 0xa2, 0x8a, 0x45, 0x81, 0x49, 0x0a, 0x8e, 0x84, 0x82, 0xcc, 0x96, 0xbc, 0x91, 0x4f, 0x82, 0xcd, 0x20, 0x53, 0x70, 0x69, 0x65, 0x67, 0x65, 0x6c, 0x20, 0x82, 0xc5, 0x82, 0xb7, 0x81, 0x42
 }
 
-/* Copyright 2020 Spiegel
+func ExampleConvertWithOptions() {
+	buf := &bytes.Buffer{}
+	if _, err := enc.ConvertWithOptions("Shift_JIS", buf, "UTF-8", strings.NewReader("寿司🍣"), &enc.Options{Fallback: enc.FallbackXML}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if err := dump.Octet(os.Stdout, buf); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	//Output:
+	//0x8e, 0xf5, 0x8e, 0x69, 0x26, 0x23, 0x78, 0x31, 0x46, 0x33, 0x36, 0x33, 0x3b
+}
+
+func ExampleRemapString() {
+	fmt.Println(enc.RemapString(enc.ProfileCP932, enc.ProfileJIS, "〜−‖¢£¬"))
+	//Output:
+	//～－∥￠￡￢
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -0,0 +1,116 @@
+package enc
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+//Fallback is type of fallback mode for unencodable characters
+type Fallback int
+
+const (
+	FallbackNone     Fallback = iota //abort conversion with error (default)
+	FallbackSkip                     //skip unencodable character
+	FallbackQuestion                 //replace with '?'
+	FallbackGeta                     //replace with U+3013 (GETA MARK)
+	FallbackHTML                     //replace with HTML decimal character reference (&#NNNN;)
+	FallbackXML                      //replace with XML hexadecimal character reference (&#xHHHH;)
+	FallbackJava                     //replace with Java style escape (\uHHHH)
+	FallbackSubchar                  //replace with substitution string defined by user
+)
+
+var fallbackNamesMap = map[string]Fallback{
+	"none":     FallbackNone,
+	"skip":     FallbackSkip,
+	"question": FallbackQuestion,
+	"geta":     FallbackGeta,
+	"html":     FallbackHTML,
+	"xml":      FallbackXML,
+	"java":     FallbackJava,
+	"subchar":  FallbackSubchar,
+}
+
+//defaultSubchar is substitution string for FallbackSubchar mode if it is not defined
+const defaultSubchar = "?"
+
+func (f Fallback) String() string {
+	return fallbackName(f)
+}
+
+func fallbackName(f Fallback) string {
+	for key, value := range fallbackNamesMap {
+		if value == f {
+			return key
+		}
+	}
+	return ""
+}
+
+//FallbackList returns list of fallback mode
+func FallbackList() []string {
+	return []string{
+		fallbackName(FallbackNone),
+		fallbackName(FallbackSkip),
+		fallbackName(FallbackQuestion),
+		fallbackName(FallbackGeta),
+		fallbackName(FallbackHTML),
+		fallbackName(FallbackXML),
+		fallbackName(FallbackJava),
+		fallbackName(FallbackSubchar),
+	}
+}
+
+//FallbackOf returns fallback mode from name string
+func FallbackOf(name string) (Fallback, error) {
+	if f, ok := fallbackNamesMap[strings.ToLower(name)]; ok {
+		return f, nil
+	}
+	return FallbackNone, errs.Wrap(ecode.ErrInvalidFallback, errs.WithContext("name", name))
+}
+
+//replacement returns substitution string of unencodable character
+func (f Fallback) replacement(r rune, subchar string) string {
+	switch f {
+	case FallbackSkip:
+		return ""
+	case FallbackQuestion:
+		return "?"
+	case FallbackGeta:
+		return "〓"
+	case FallbackHTML:
+		return fmt.Sprintf("&#%d;", r)
+	case FallbackXML:
+		return fmt.Sprintf("&#x%X;", r)
+	case FallbackJava:
+		if r1, r2 := utf16.EncodeRune(r); r1 != 0xfffd || r2 != 0xfffd {
+			return fmt.Sprintf("\\u%04X\\u%04X", r1, r2)
+		}
+		return fmt.Sprintf("\\u%04X", r)
+	case FallbackSubchar:
+		if len(subchar) == 0 {
+			return defaultSubchar
+		}
+		return subchar
+	default:
+		return ""
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,140 @@
+//go:build run
+// +build run
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+//readAliases reads ianaAliases table in tables.go of golang.org/x/text/encoding/ianaindex package directory.
+//It returns lists of names (canonical name and aliases) in order of the table.
+func readAliases(dir string) ([][]string, error) {
+	path := filepath.Join(dir, "tables.go")
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	lists := [][]string{}
+	index := map[string]int{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "ianaAliases" || len(spec.Values) != 1 {
+			return true
+		}
+		lit, ok := spec.Values[0].(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			key, ok := kv.Key.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			id, ok := kv.Value.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			name, err := strconv.Unquote(key.Value)
+			if err != nil {
+				continue
+			}
+			i, ok := index[id.Name]
+			if !ok {
+				i = len(lists)
+				index[id.Name] = i
+				lists = append(lists, []string{})
+			}
+			dup := false
+			for _, s := range lists[i] {
+				if strings.EqualFold(s, name) {
+					dup = true
+					break
+				}
+			}
+			if !dup {
+				lists[i] = append(lists[i], name)
+			}
+		}
+		return false
+	})
+	if len(lists) == 0 {
+		return nil, fmt.Errorf("ianaAliases table is not found in %s", path)
+	}
+	return lists, nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run -tags run iana-table/main.go <directory of golang.org/x/text/encoding/ianaindex package>")
+		return
+	}
+	lists, err := readAliases(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println("// Code generated by iana-table/main.go from tables.go in golang.org/x/text/encoding/ianaindex package; DO NOT EDIT.")
+	fmt.Println()
+	fmt.Println("package enc")
+	fmt.Println()
+	fmt.Println("// ianaNames is table of IANA character sets supported by golang.org/x/text/encoding/ianaindex package.")
+	fmt.Println("// The first name of each list is the canonical name, and the others are aliases.")
+	fmt.Println("var ianaNames = [][]string{")
+	for _, names := range lists {
+		if e, err := ianaindex.IANA.Encoding(names[0]); err != nil || e == nil {
+			continue
+		}
+		vs := []string{}
+		for _, name := range names {
+			vs = append(vs, strconv.Quote(name))
+		}
+		fmt.Printf("\t{%s},\n", strings.Join(vs, ", "))
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Print(footer)
+}
+
+const footer = `/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+`
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,122 @@
+package enc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/dump"
+	"github.com/goark/gnkf/ecode"
+	"golang.org/x/text/encoding/unicode"
+)
+
+//Invalid is information of invalid byte sequence or unencodable character in source text.
+type Invalid struct {
+	Offset      int64  //byte offset in source text (0-origin)
+	Line        int    //line number in source text (1-origin, counted by LF)
+	Column      int    //column number in source text (1-origin, counted by character)
+	Bytes       []byte //offending byte sequence in source text
+	Unencodable bool   //true if the character cannot be encoded to destination
+	Rune        rune   //unencodable character (Unencodable is true only)
+}
+
+//Error method is implementation of error interface.
+func (iv *Invalid) Error() string {
+	return iv.String()
+}
+
+//String method is implementation of fmt.Stringer interface.
+func (iv *Invalid) String() string {
+	if iv == nil {
+		return ""
+	}
+	if iv.Unencodable {
+		return fmt.Sprintf("offset %d (line %d, column %d): unencodable character %U [%s]", iv.Offset, iv.Line, iv.Column, iv.Rune, iv.sequence())
+	}
+	return fmt.Sprintf("offset %d (line %d, column %d): invalid byte sequence [%s]", iv.Offset, iv.Line, iv.Column, iv.sequence())
+}
+
+func (iv *Invalid) sequence() string {
+	return dump.OctetString(bytes.NewReader(iv.Bytes))
+}
+
+//FindInvalid returns list of invalid byte sequences in source text and characters that cannot be encoded to destination.
+func FindInvalid(toIanaName, fromIanaName string, txt io.Reader) ([]*Invalid, error) {
+	encoder, err := Encoding(toIanaName)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("toIanaName", toIanaName))
+	}
+	decoder, err := Encoding(fromIanaName)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("fromIanaName", fromIanaName))
+	}
+	var et *encodeTransformer
+	if encoder != unicode.UTF8 && encoder != decoder {
+		et = newEncodeTransformer(encoder.NewEncoder(), nil)
+	}
+	list := []*Invalid{}
+	t := newDecodeTransformer(decoder, et, nil)
+	t.report = func(iv *Invalid) {
+		list = append(list, iv)
+	}
+	if err := copyTransform(io.Discard, t, txt); err != nil {
+		return list, errs.Wrap(err, errs.WithContext("toIanaName", toIanaName), errs.WithContext("fromIanaName", fromIanaName))
+	}
+	return list, nil
+}
+
+//wrapInvalid returns ErrInvalidEncoding error with context of invalid position.
+func wrapInvalid(err error) error {
+	var iv *Invalid
+	if errors.As(err, &iv) {
+		return errs.Wrap(ecode.ErrInvalidEncoding,
+			errs.WithCause(err),
+			errs.WithContext("offset", iv.Offset),
+			errs.WithContext("line", iv.Line),
+			errs.WithContext("column", iv.Column),
+			errs.WithContext("sequence", iv.sequence()),
+		)
+	}
+	return errs.Wrap(ecode.ErrInvalidEncoding, errs.WithCause(err))
+}
+
+//position is current position in source text.
+type position struct {
+	offset int64
+	line   int
+	column int
+}
+
+//forward moves position by consumed bytes in source text and decoded UTF-8 text.
+func (p *position) forward(consumed int, txt []byte) {
+	p.offset += int64(consumed)
+	if i := bytes.LastIndexByte(txt, '\n'); i >= 0 {
+		p.line += bytes.Count(txt, []byte{'\n'})
+		p.column = 0
+		txt = txt[i+1:]
+	}
+	p.column += utf8.RuneCount(txt)
+}
+
+//invalid returns Invalid instance at current position.
+func (p *position) invalid(b []byte) *Invalid {
+	return &Invalid{Offset: p.offset, Line: p.line + 1, Column: p.column + 1, Bytes: append([]byte{}, b...)}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
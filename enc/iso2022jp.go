@@ -0,0 +1,480 @@
+package enc
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/goark/gnkf/width"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+//kanaMode is type of encoding mode for half-width katakana in ISO-2022-JP variants
+type kanaMode int
+
+const (
+	kanaNone kanaMode = iota //half-width katakana is unencodable
+	kanaFold                 //fold half-width katakana into full-width
+	kanaESC                  //JIS X 0201 katakana designated by ESC ( I
+	kanaSO                   //JIS X 0201 katakana invoked by SO/SI
+)
+
+//iso2022JP is encoding.Encoding for variants of ISO-2022-JP (CP5022x, ISO-2022-JP-1, ISO-2022-JP-3, and ISO-2022-JP-2004).
+//Decoders accept all character sets of the variants.
+type iso2022JP struct {
+	kana    kanaMode //encoding mode for half-width katakana
+	cp932   bool     //JIS X 0208 with CP932 extensions (NEC special characters and IBM extensions) and mapping
+	jis0212 bool     //JIS X 0212 designated by ESC $ ( D (and JIS X 0201 Roman designated by ESC ( J)
+	jis0213 byte     //final byte of designation for JIS X 0213 plane 1 ('O' or 'Q'), 0 if not available
+}
+
+var (
+	cp50220       = iso2022JP{kana: kanaFold, cp932: true}
+	cp50221       = iso2022JP{kana: kanaESC, cp932: true}
+	cp50222       = iso2022JP{kana: kanaSO, cp932: true}
+	iso2022JP1    = iso2022JP{jis0212: true}
+	iso2022JP3    = iso2022JP{jis0213: 'O'}
+	iso2022JP2004 = iso2022JP{jis0213: 'Q'}
+)
+
+//NewDecoder method is implementation of encoding.Encoding interface.
+func (e iso2022JP) NewDecoder() *encoding.Decoder {
+	initJISTables()
+	return &encoding.Decoder{Transformer: &iso2022JPDecoder{jisx0213: !e.cp932}}
+}
+
+//NewEncoder method is implementation of encoding.Encoding interface.
+func (e iso2022JP) NewEncoder() *encoding.Encoder {
+	initJISTables()
+	initJISX0213Tables()
+	return &encoding.Encoder{Transformer: &iso2022JPEncoder{iso2022JP: e}}
+}
+
+//charset is type of character set in ISO-2022-JP
+type charset int
+
+const (
+	setASCII      charset = iota //ESC ( B
+	setRoman                     //JIS X 0201 Roman: ESC ( J
+	setKana                      //JIS X 0201 Katakana: ESC ( I
+	setJIS0208                   //ESC $ @, ESC $ B
+	setJIS0212                   //ESC $ ( D
+	setJISX0213P1                //ESC $ ( O, ESC $ ( Q
+	setJISX0213P2                //ESC $ ( P
+)
+
+const (
+	asciiESC = 0x1b
+	asciiSO  = 0x0e
+	asciiSI  = 0x0f
+)
+
+var designations = []struct {
+	seq []byte
+	set charset
+}{
+	{seq: []byte("\x1b(B"), set: setASCII},
+	{seq: []byte("\x1b(J"), set: setRoman},
+	{seq: []byte("\x1b(I"), set: setKana},
+	{seq: []byte("\x1b$@"), set: setJIS0208},
+	{seq: []byte("\x1b$B"), set: setJIS0208},
+	{seq: []byte("\x1b$(B"), set: setJIS0208},
+	{seq: []byte("\x1b$(D"), set: setJIS0212},
+	{seq: []byte("\x1b$(O"), set: setJISX0213P1},
+	{seq: []byte("\x1b$(Q"), set: setJISX0213P1},
+	{seq: []byte("\x1b$(P"), set: setJISX0213P2},
+}
+
+//designation returns character set and length of escape sequence.
+//If src is a part of escape sequence, it returns length 0 and true.
+func designation(src []byte) (charset, int, bool) {
+	short := false
+	for _, d := range designations {
+		if bytes.HasPrefix(src, d.seq) {
+			return d.set, len(d.seq), false
+		}
+		if len(src) < len(d.seq) && bytes.HasPrefix(d.seq, src) {
+			short = true
+		}
+	}
+	return setASCII, 0, short
+}
+
+//iso2022JPDecoder is transform.Transformer for decoding ISO-2022-JP variants
+type iso2022JPDecoder struct {
+	jisx0213 bool //decode JIS X 0208 by JIS X 0213 table (JIS mapping)
+	set      charset
+	shifted  bool //SO is invoked
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (d *iso2022JPDecoder) Reset() {
+	d.set = setASCII
+	d.shifted = false
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (d *iso2022JPDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	var buf [8]byte
+	for nSrc < len(src) {
+		c := src[nSrc]
+		size := 1
+		var rs []rune
+		switch {
+		case c == asciiESC:
+			set, n, short := designation(src[nSrc:])
+			if short && !atEOF {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			if n > 0 {
+				d.set = set
+				nSrc += n
+				continue
+			}
+			rs = []rune{utf8.RuneError}
+		case c == asciiSO:
+			d.shifted = true
+			nSrc++
+			continue
+		case c == asciiSI:
+			d.shifted = false
+			nSrc++
+			continue
+		case c >= utf8.RuneSelf:
+			rs = []rune{utf8.RuneError}
+		case d.shifted && 0x21 <= c && c <= 0x5f:
+			rs = []rune{rune(c) - 0x21 + 0xff61}
+		case c < 0x21 || c == 0x7f:
+			rs = []rune{rune(c)}
+		default:
+			switch d.set {
+			case setASCII:
+				rs = []rune{rune(c)}
+			case setRoman:
+				switch c {
+				case 0x5c:
+					rs = []rune{0xa5}
+				case 0x7e:
+					rs = []rune{0x203e}
+				default:
+					rs = []rune{rune(c)}
+				}
+			case setKana:
+				if c <= 0x5f {
+					rs = []rune{rune(c) - 0x21 + 0xff61}
+				} else {
+					rs = []rune{utf8.RuneError}
+				}
+			default:
+				if nSrc+1 >= len(src) {
+					if !atEOF {
+						return nDst, nSrc, transform.ErrShortSrc
+					}
+					rs = []rune{utf8.RuneError}
+					break
+				}
+				c2 := src[nSrc+1]
+				if c2 < 0x21 || 0x7e < c2 {
+					rs = []rune{utf8.RuneError}
+					break
+				}
+				size = 2
+				if rs = d.lookup(int(c-0x21)*94 + int(c2-0x21)); len(rs) == 0 {
+					rs = []rune{utf8.RuneError}
+				}
+			}
+		}
+		b := buf[:0]
+		for _, r := range rs {
+			b = utf8.AppendRune(b, r)
+		}
+		if nDst+len(b) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+//lookup returns characters of double-byte character set.
+func (d *iso2022JPDecoder) lookup(idx int) []rune {
+	switch d.set {
+	case setJIS0208:
+		if d.jisx0213 {
+			return decodeJISX0213(idx)
+		}
+		if r := jis0208Table.decode[idx]; r != 0 {
+			return []rune{r}
+		}
+	case setJIS0212:
+		if r := jis0212Table.decode[idx]; r != 0 {
+			return []rune{r}
+		}
+	case setJISX0213P1:
+		return decodeJISX0213(idx)
+	case setJISX0213P2:
+		return decodeJISX0213(94*94 + idx)
+	}
+	return nil
+}
+
+//unencodableError is error for unencodable character (compatible with golang.org/x/text/encoding/internal.RepertoireError)
+type unencodableError struct{}
+
+func (unencodableError) Error() string {
+	return "encoding: rune not supported by encoding."
+}
+
+//Replacement method returns replacement character of unencodable character.
+func (unencodableError) Replacement() byte {
+	return 0x1a
+}
+
+var errUnencodable = unencodableError{}
+
+//iso2022JPEncoder is transform.Transformer for encoding ISO-2022-JP variants
+type iso2022JPEncoder struct {
+	iso2022JP
+	set     charset
+	shifted bool   //SO is invoked
+	held    rune   //character held for next character (combining sequence or voiced sound mark)
+	buf     []byte //buffer for encoding a character
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (e *iso2022JPEncoder) Reset() {
+	e.set = setASCII
+	e.shifted = false
+	e.held = 0
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (e *iso2022JPEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := rune(src[nSrc]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(src[nSrc:])
+			if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+		}
+		set, shifted, held := e.set, e.shifted, e.held
+		b, combined, err := e.appendHeld(e.buf[:0], r)
+		if err == nil && !combined {
+			if e.holds(r) {
+				e.held = r
+			} else if b2, rerr := e.appendRune(b, r); rerr != nil {
+				//write held character before unencodable character
+				if nDst+len(b) > len(dst) {
+					e.set, e.shifted, e.held = set, shifted, held
+					return nDst, nSrc, transform.ErrShortDst
+				}
+				nDst += copy(dst[nDst:], b)
+				return nDst, nSrc, rerr
+			} else {
+				b = b2
+			}
+		}
+		if err == nil && nDst+len(b) > len(dst) {
+			err = transform.ErrShortDst
+		}
+		if err != nil {
+			e.set, e.shifted, e.held = set, shifted, held
+			return nDst, nSrc, err
+		}
+		e.buf = b
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	if atEOF {
+		set, shifted, held := e.set, e.shifted, e.held
+		b, _, err := e.appendHeld(e.buf[:0], -1)
+		if err == nil {
+			b = e.appendDesignation(b, setASCII, false)
+			if nDst+len(b) > len(dst) {
+				err = transform.ErrShortDst
+			}
+		}
+		if err != nil {
+			e.set, e.shifted, e.held = set, shifted, held
+			return nDst, nSrc, err
+		}
+		nDst += copy(dst[nDst:], b)
+	}
+	return nDst, nSrc, nil
+}
+
+//holds returns true if the character is held for next character.
+func (e *iso2022JPEncoder) holds(r rune) bool {
+	if e.kana == kanaFold && isHalfwidthKana(r) {
+		return true
+	}
+	return e.jis0213 != 0 && jisx0213Bases[r]
+}
+
+//appendHeld appends held character (and next character if they are combined).
+//If next is negative, it flushes held character.
+func (e *iso2022JPEncoder) appendHeld(b []byte, next rune) ([]byte, bool, error) {
+	h := e.held
+	if h == 0 {
+		return b, false, nil
+	}
+	e.held = 0
+	if isHalfwidthKana(h) {
+		if next == 0xff9e || next == 0xff9f {
+			if rs := []rune(widenKana(string([]rune{h, next}))); len(rs) == 1 {
+				if b2, err := e.appendRune(b, rs[0]); err == nil {
+					return b2, true, nil
+				}
+			}
+		}
+		b, err := e.appendRune(b, foldKana(h))
+		return b, false, err
+	}
+	if idx, ok := jisx0213Compose[[2]rune{h, next}]; ok {
+		return e.appendJISX0213(b, idx), true, nil
+	}
+	b, err := e.appendRune(b, h)
+	return b, false, err
+}
+
+//appendRune appends encoded character.
+func (e *iso2022JPEncoder) appendRune(b []byte, r rune) ([]byte, error) {
+	switch {
+	case r < utf8.RuneSelf:
+		return append(e.appendDesignation(b, setASCII, false), byte(r)), nil
+	case isHalfwidthKana(r):
+		switch e.kana {
+		case kanaFold:
+			return e.appendRune(b, foldKana(r))
+		case kanaESC:
+			return append(e.appendDesignation(b, setKana, false), byte(r-0xff61+0x21)), nil
+		case kanaSO:
+			return append(e.appendDesignation(b, setASCII, true), byte(r-0xff61+0x21)), nil
+		}
+		return b, errUnencodable
+	case e.cp932:
+		if idx, ok := jis0208Table.encode[r]; ok {
+			return appendDoubleByte(e.appendDesignation(b, setJIS0208, false), idx), nil
+		}
+		return b, errUnencodable
+	}
+	if idx, ok := jisx0213Encode[r]; ok {
+		if isJIS0208Standard(idx) {
+			return appendDoubleByte(e.appendDesignation(b, setJIS0208, false), idx), nil
+		}
+		if e.jis0213 != 0 {
+			if e.jis0213 == 'O' && jisx0213Added2004[idx] {
+				return b, errUnencodable
+			}
+			return e.appendJISX0213(b, idx), nil
+		}
+	}
+	//accept characters of CP932 mapping (～, ∥, －, and so on)
+	if idx, ok := jis0208Table.encode[r]; ok && isJIS0208Standard(idx) {
+		return appendDoubleByte(e.appendDesignation(b, setJIS0208, false), idx), nil
+	}
+	if e.jis0212 {
+		if idx, ok := jis0212Table.encode[r]; ok {
+			return appendDoubleByte(e.appendDesignation(b, setJIS0212, false), idx), nil
+		}
+		switch r {
+		case 0xa5: //YEN SIGN in JIS X 0201 Roman
+			return append(e.appendDesignation(b, setRoman, false), 0x5c), nil
+		case 0x203e: //OVERLINE in JIS X 0201 Roman
+			return append(e.appendDesignation(b, setRoman, false), 0x7e), nil
+		}
+	}
+	return b, errUnencodable
+}
+
+//appendJISX0213 appends character of JIS X 0213 (index of jisx0213Table).
+//Characters in JIS X 0208 are designated by ESC $ B.
+func (e *iso2022JPEncoder) appendJISX0213(b []byte, idx int) []byte {
+	switch {
+	case idx >= 94*94:
+		return appendDoubleByte(e.appendDesignation(b, setJISX0213P2, false), idx-94*94)
+	case isJIS0208Standard(idx):
+		return appendDoubleByte(e.appendDesignation(b, setJIS0208, false), idx)
+	}
+	return appendDoubleByte(e.appendDesignation(b, setJISX0213P1, false), idx)
+}
+
+//appendDesignation appends escape sequence (and SO/SI) for the character set.
+func (e *iso2022JPEncoder) appendDesignation(b []byte, set charset, shifted bool) []byte {
+	if e.shifted && !shifted {
+		b = append(b, asciiSI)
+		e.shifted = false
+	}
+	if e.set != set {
+		switch set {
+		case setASCII:
+			b = append(b, "\x1b(B"...)
+		case setRoman:
+			b = append(b, "\x1b(J"...)
+		case setKana:
+			b = append(b, "\x1b(I"...)
+		case setJIS0208:
+			b = append(b, "\x1b$B"...)
+		case setJIS0212:
+			b = append(b, "\x1b$(D"...)
+		case setJISX0213P1:
+			b = append(b, '\x1b', '$', '(', e.jis0213)
+		case setJISX0213P2:
+			b = append(b, "\x1b$(P"...)
+		}
+		e.set = set
+	}
+	if !e.shifted && shifted {
+		b = append(b, asciiSO)
+		e.shifted = true
+	}
+	return b
+}
+
+func appendDoubleByte(b []byte, idx int) []byte {
+	return append(b, byte(idx/94+0x21), byte(idx%94+0x21))
+}
+
+func isHalfwidthKana(r rune) bool {
+	return 0xff61 <= r && r <= 0xff9f
+}
+
+//foldKana returns full-width character of half-width katakana.
+func foldKana(r rune) rune {
+	switch r {
+	case 0xff9e:
+		return 0x309b //KATAKANA-HIRAGANA VOICED SOUND MARK
+	case 0xff9f:
+		return 0x309c //KATAKANA-HIRAGANA SEMI-VOICED SOUND MARK
+	}
+	if rs := []rune(widenKana(string(r))); len(rs) == 1 {
+		return rs[0]
+	}
+	return r
+}
+
+//widenKana converts half-width katakana to full-width by width package.
+func widenKana(s string) string {
+	w, err := width.ConvertString("widen", s)
+	if err != nil {
+		return s
+	}
+	return w
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,121 @@
+package enc
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+)
+
+//jisTable is mapping table of 94x94 character set
+type jisTable struct {
+	decode [94 * 94]rune
+	encode map[rune]int
+}
+
+var (
+	jis0208Table     *jisTable //JIS X 0208 with CP932 extensions (table of golang.org/x/text/encoding/japanese package)
+	jis0212Table     *jisTable //JIS X 0212 (table of golang.org/x/text/encoding/japanese package)
+	jisx0213Encode   map[rune]int
+	jisx0213Compose  map[[2]rune]int
+	jisx0213Bases    map[rune]bool
+	jisTableOnce     sync.Once
+	jisx0213InitOnce sync.Once
+)
+
+//initJISTables makes JIS X 0208 and JIS X 0212 tables from EUC-JP decoder in golang.org/x/text/encoding/japanese package.
+func initJISTables() {
+	jisTableOnce.Do(func() {
+		jis0208Table = newJISTable(nil)
+		jis0212Table = newJISTable([]byte{0x8f})
+	})
+}
+
+func newJISTable(prefix []byte) *jisTable {
+	t := &jisTable{encode: map[rune]int{}}
+	decoder := japanese.EUCJP.NewDecoder()
+	src := append(prefix, 0, 0)
+	buf := make([]byte, 16)
+	for idx := 0; idx < 94*94; idx++ {
+		src[len(prefix)], src[len(prefix)+1] = byte(idx/94+0xa1), byte(idx%94+0xa1)
+		decoder.Reset()
+		n, _, err := decoder.Transform(buf, src, true)
+		if err != nil {
+			continue
+		}
+		r, size := utf8.DecodeRune(buf[:n])
+		if r == utf8.RuneError || size != n {
+			continue
+		}
+		t.decode[idx] = r
+		if _, ok := t.encode[r]; !ok {
+			t.encode[r] = idx
+		}
+	}
+	return t
+}
+
+//isJIS0208Standard returns true if the character is defined in JIS X 0208 (without CP932 extensions).
+func isJIS0208Standard(idx int) bool {
+	initJISTables()
+	row := idx / 94
+	if row < 8 || (15 <= row && row < 84) {
+		return jis0208Table.decode[idx] != 0
+	}
+	return false
+}
+
+//jisx0213Compatible is table of characters mapped to JIS X 0213 in encoding only.
+//U+2015 (HORIZONTAL BAR) is mapped to 1-1-29 (U+2014) by CP932 and golang.org/x/text package.
+var jisx0213Compatible = map[rune]rune{
+	0x2015: 0x2014,
+}
+
+//initJISX0213Tables makes reverse tables of JIS X 0213.
+func initJISX0213Tables() {
+	jisx0213InitOnce.Do(func() {
+		jisx0213Encode = map[rune]int{}
+		for idx, r := range jisx0213Table {
+			if r != 0 {
+				jisx0213Encode[r] = idx
+			}
+		}
+		for r, c := range jisx0213Compatible {
+			if _, ok := jisx0213Encode[r]; !ok {
+				jisx0213Encode[r] = jisx0213Encode[c]
+			}
+		}
+		jisx0213Compose = map[[2]rune]int{}
+		jisx0213Bases = map[rune]bool{}
+		for idx, rs := range jisx0213Combining {
+			jisx0213Compose[rs] = idx
+			jisx0213Bases[rs[0]] = true
+		}
+	})
+}
+
+//decodeJISX0213 returns characters of JIS X 0213 (index of jisx0213Table).
+func decodeJISX0213(idx int) []rune {
+	if r := jisx0213Table[idx]; r != 0 {
+		return []rune{r}
+	}
+	if rs, ok := jisx0213Combining[idx]; ok {
+		return rs[:]
+	}
+	return nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,145 @@
+//go:build run
+// +build run
+
+package main
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//go:embed jisx0213-2004-std.txt
+var mappingList string
+
+const tableSize = 2 * 94 * 94
+
+type mapping struct {
+	table     [tableSize]rune
+	combining map[int][2]rune
+	added     []int
+	comments  map[int]string
+}
+
+func readData() (*mapping, error) {
+	m := &mapping{combining: map[int][2]rune{}, comments: map[int]string{}}
+	sc := bufio.NewScanner(strings.NewReader(mappingList))
+	for sc.Scan() {
+		line := sc.Text()
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid line: %q", line)
+		}
+		var plane, code int
+		if _, err := fmt.Sscanf(fields[0], "%d-%X", &plane, &code); err != nil {
+			return nil, fmt.Errorf("invalid JIS code: %q: %w", line, err)
+		}
+		idx := (plane-3)*94*94 + (code>>8-0x21)*94 + (code&0xff - 0x21)
+		var rs []rune
+		for _, u := range strings.Split(fields[1], "+U+") {
+			v, err := strconv.ParseInt(strings.TrimPrefix(u, "U+"), 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Unicode: %q: %w", line, err)
+			}
+			rs = append(rs, rune(v))
+		}
+		switch len(rs) {
+		case 1:
+			m.table[idx] = rs[0]
+		case 2:
+			m.combining[idx] = [2]rune{rs[0], rs[1]}
+			m.comments[idx] = fmt.Sprintf("%s %s", fields[0], strings.TrimPrefix(fields[2], "# "))
+		default:
+			return nil, fmt.Errorf("invalid Unicode sequence: %q", line)
+		}
+		if len(fields) > 3 && fields[3] == "[2004]" {
+			m.added = append(m.added, idx)
+			m.comments[idx] = fmt.Sprintf("%s %s", fields[0], strings.TrimPrefix(fields[2], "# "))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func main() {
+	m, err := readData()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println("// Code generated by jisx0213-table/main.go from jisx0213-table/jisx0213-2004-std.txt; DO NOT EDIT.")
+	fmt.Println()
+	fmt.Println("package enc")
+	fmt.Println()
+	fmt.Println("// jisx0213Table is mapping table from JIS X 0213:2004 to Unicode.")
+	fmt.Println("// Index is (plane-1)*94*94 + (row-1)*94 + (cell-1). Characters mapped to combining sequences are in jisx0213Combining table.")
+	fmt.Println("var jisx0213Table = [2 * 94 * 94]rune{")
+	for row := 0; row < 2*94; row++ {
+		fmt.Printf("\t//plane %d, row %d\n", row/94+1, row%94+1)
+		for i := row * 94; i < (row+1)*94; i += 16 {
+			vs := []string{}
+			for _, r := range m.table[i:min(i+16, (row+1)*94)] {
+				vs = append(vs, fmt.Sprintf("%#04x,", r))
+			}
+			fmt.Printf("\t%s\n", strings.Join(vs, " "))
+		}
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Println("// jisx0213Combining is table of JIS X 0213 characters mapped to combining sequences (index of jisx0213Table).")
+	fmt.Println("var jisx0213Combining = map[int][2]rune{")
+	for i := 0; i < tableSize; i++ {
+		if c, ok := m.combining[i]; ok {
+			fmt.Printf("\t%#04x: {%#04x, %#04x}, // %s\n", i, c[0], c[1], m.comments[i])
+		}
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Println("// jisx0213Added2004 is set of characters added in JIS X 0213:2004 (index of jisx0213Table).")
+	fmt.Println("var jisx0213Added2004 = map[int]bool{")
+	for _, i := range m.added {
+		fmt.Printf("\t%#04x: true, // %s\n", i, m.comments[i])
+	}
+	fmt.Println("}")
+	fmt.Println()
+	fmt.Print(footer)
+}
+
+const footer = `/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+`
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,308 @@
+package enc
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+//jisx0213Encoding is encoding.Encoding for Shift_JIS-2004 and EUC-JIS-2004 (JIS X 0213:2004).
+type jisx0213Encoding struct {
+	sjis bool //Shift_JIS-2004 if true, EUC-JIS-2004 if false
+}
+
+var (
+	shiftJIS2004 = jisx0213Encoding{sjis: true}
+	eucJIS2004   = jisx0213Encoding{sjis: false}
+)
+
+//NewDecoder method is implementation of encoding.Encoding interface.
+func (e jisx0213Encoding) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: &jisx0213Decoder{sjis: e.sjis}}
+}
+
+//NewEncoder method is implementation of encoding.Encoding interface.
+func (e jisx0213Encoding) NewEncoder() *encoding.Encoder {
+	initJISX0213Tables()
+	return &encoding.Encoder{Transformer: &jisx0213Encoder{sjis: e.sjis}}
+}
+
+//jisx0213Decoder is transform.Transformer for decoding Shift_JIS-2004 and EUC-JIS-2004
+type jisx0213Decoder struct {
+	transform.NopResetter
+	sjis bool
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (d *jisx0213Decoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	var buf [8]byte
+	for nSrc < len(src) {
+		var rs []rune
+		size, short := 1, false
+		if d.sjis {
+			rs, size, short = decodeSJIS2004(src[nSrc:])
+		} else {
+			rs, size, short = decodeEUCJIS2004(src[nSrc:])
+		}
+		if short {
+			if !atEOF {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+			rs, size = []rune{utf8.RuneError}, 1
+		}
+		b := buf[:0]
+		for _, r := range rs {
+			b = utf8.AppendRune(b, r)
+		}
+		if nDst+len(b) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+//decodeSJIS2004 decodes a character of Shift_JIS-2004.
+//It returns true if src is a part of character.
+func decodeSJIS2004(src []byte) ([]rune, int, bool) {
+	c := src[0]
+	switch {
+	case c < utf8.RuneSelf:
+		return []rune{rune(c)}, 1, false
+	case 0xa1 <= c && c <= 0xdf:
+		return []rune{rune(c) - 0xa1 + 0xff61}, 1, false
+	case (c < 0x81 || 0x9f < c) && (c < 0xe0 || 0xfc < c):
+		return []rune{utf8.RuneError}, 1, false
+	}
+	if len(src) < 2 {
+		return nil, 1, true
+	}
+	idx, ok := sjisIndex(c, src[1])
+	if !ok {
+		return []rune{utf8.RuneError}, 1, false
+	}
+	if rs := decodeJISX0213(idx); len(rs) > 0 {
+		return rs, 2, false
+	}
+	return []rune{utf8.RuneError}, 2, false
+}
+
+//decodeEUCJIS2004 decodes a character of EUC-JIS-2004.
+//It returns true if src is a part of character.
+func decodeEUCJIS2004(src []byte) ([]rune, int, bool) {
+	c := src[0]
+	switch {
+	case c < utf8.RuneSelf:
+		return []rune{rune(c)}, 1, false
+	case c == 0x8e:
+		if len(src) < 2 {
+			return nil, 1, true
+		}
+		if 0xa1 <= src[1] && src[1] <= 0xdf {
+			return []rune{rune(src[1]) - 0xa1 + 0xff61}, 2, false
+		}
+		return []rune{utf8.RuneError}, 1, false
+	case c == 0x8f:
+		if len(src) < 3 {
+			if len(src) == 2 && !isEUCByte(src[1]) {
+				return []rune{utf8.RuneError}, 1, false
+			}
+			return nil, 1, true
+		}
+		if !isEUCByte(src[1]) || !isEUCByte(src[2]) {
+			return []rune{utf8.RuneError}, 1, false
+		}
+		if rs := decodeJISX0213(94*94 + int(src[1]-0xa1)*94 + int(src[2]-0xa1)); len(rs) > 0 {
+			return rs, 3, false
+		}
+		return []rune{utf8.RuneError}, 3, false
+	case !isEUCByte(c):
+		return []rune{utf8.RuneError}, 1, false
+	}
+	if len(src) < 2 {
+		return nil, 1, true
+	}
+	if !isEUCByte(src[1]) {
+		return []rune{utf8.RuneError}, 1, false
+	}
+	if rs := decodeJISX0213(int(c-0xa1)*94 + int(src[1]-0xa1)); len(rs) > 0 {
+		return rs, 2, false
+	}
+	return []rune{utf8.RuneError}, 2, false
+}
+
+func isEUCByte(c byte) bool {
+	return 0xa1 <= c && c <= 0xfe
+}
+
+//sjisPlane2Rows is table of rows in JIS X 0213 plane 2 for lead bytes 0xF0 to 0xF4 of Shift_JIS-2004.
+var sjisPlane2Rows = [5][2]int{{1, 8}, {3, 4}, {5, 12}, {13, 14}, {15, 78}}
+
+//sjisIndex returns index of jisx0213Table from double-byte character of Shift_JIS-2004.
+func sjisIndex(lead, trail byte) (int, bool) {
+	if trail < 0x40 || trail == 0x7f || 0xfc < trail {
+		return 0, false
+	}
+	half, cell := 0, int(trail)-0x3f
+	switch {
+	case trail >= 0x9f:
+		half, cell = 1, int(trail)-0x9e
+	case trail > 0x7f:
+		cell--
+	}
+	plane, row := 0, 0
+	switch {
+	case lead <= 0x9f:
+		row = int(lead-0x81)*2 + 1 + half
+	case lead <= 0xef:
+		row = int(lead-0xe0)*2 + 63 + half
+	case lead <= 0xf4:
+		plane, row = 1, sjisPlane2Rows[lead-0xf0][half]
+	default:
+		plane, row = 1, int(lead-0xf5)*2+79+half
+	}
+	return plane*94*94 + (row-1)*94 + (cell - 1), true
+}
+
+//sjisCode returns double-byte character of Shift_JIS-2004 from index of jisx0213Table.
+func sjisCode(idx int) (byte, byte) {
+	plane, row, cell := idx/(94*94), idx%(94*94)/94+1, idx%94+1
+	var lead, half int
+	switch {
+	case plane == 0 && row <= 62:
+		lead, half = (row+0x101)/2, 1-row%2
+	case plane == 0:
+		lead, half = (row+0x181)/2, 1-row%2
+	case row >= 79:
+		lead, half = (row+0x19b)/2, 1-row%2
+	default:
+		for i, rows := range sjisPlane2Rows {
+			for j, r := range rows {
+				if r == row {
+					lead, half = 0xf0+i, j
+				}
+			}
+		}
+	}
+	if half == 1 {
+		return byte(lead), byte(cell + 0x9e)
+	}
+	if cell >= 64 {
+		return byte(lead), byte(cell + 0x40)
+	}
+	return byte(lead), byte(cell + 0x3f)
+}
+
+//jisx0213Encoder is transform.Transformer for encoding Shift_JIS-2004 and EUC-JIS-2004
+type jisx0213Encoder struct {
+	sjis bool
+	held rune   //base character held for combining sequence
+	buf  []byte //buffer for encoding a character
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (e *jisx0213Encoder) Reset() {
+	e.held = 0
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (e *jisx0213Encoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := rune(src[nSrc]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(src[nSrc:])
+			if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+		}
+		held := e.held
+		b := e.buf[:0]
+		combined := false
+		if held != 0 {
+			e.held = 0
+			if idx, ok := jisx0213Compose[[2]rune{held, r}]; ok {
+				b, combined = e.appendCode(b, idx), true
+			} else {
+				b, _ = e.appendRune(b, held)
+			}
+		}
+		if !combined {
+			if jisx0213Bases[r] {
+				e.held = r
+			} else if b2, rerr := e.appendRune(b, r); rerr != nil {
+				//write held character before unencodable character
+				if nDst+len(b) > len(dst) {
+					e.held = held
+					return nDst, nSrc, transform.ErrShortDst
+				}
+				nDst += copy(dst[nDst:], b)
+				return nDst, nSrc, rerr
+			} else {
+				b = b2
+			}
+		}
+		if nDst+len(b) > len(dst) {
+			e.held = held
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		e.buf = b
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	if atEOF && e.held != 0 {
+		b, _ := e.appendRune(e.buf[:0], e.held)
+		if nDst+len(b) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], b)
+		e.held = 0
+	}
+	return nDst, nSrc, nil
+}
+
+//appendRune appends encoded character.
+func (e *jisx0213Encoder) appendRune(b []byte, r rune) ([]byte, error) {
+	switch {
+	case r < utf8.RuneSelf:
+		return append(b, byte(r)), nil
+	case isHalfwidthKana(r):
+		if e.sjis {
+			return append(b, byte(r-0xff61+0xa1)), nil
+		}
+		return append(b, 0x8e, byte(r-0xff61+0xa1)), nil
+	}
+	if idx, ok := jisx0213Encode[r]; ok {
+		return e.appendCode(b, idx), nil
+	}
+	return b, errUnencodable
+}
+
+//appendCode appends character of JIS X 0213 (index of jisx0213Table).
+func (e *jisx0213Encoder) appendCode(b []byte, idx int) []byte {
+	if e.sjis {
+		lead, trail := sjisCode(idx)
+		return append(b, lead, trail)
+	}
+	if idx >= 94*94 {
+		idx -= 94 * 94
+		b = append(b, 0x8f)
+	}
+	return append(b, byte(idx/94+0xa1), byte(idx%94+0xa1))
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
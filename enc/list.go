@@ -0,0 +1,93 @@
+package enc
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+)
+
+//Info is information of character encoding supported by Encoding function.
+type Info struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+	MIME    string   `json:"mime,omitempty"`
+	Encode  bool     `json:"encode"`
+	Decode  bool     `json:"decode"`
+}
+
+//List returns list of character encodings supported by Encoding function.
+//Encodings implemented in this package are first, and IANA character sets follow.
+func List() []Info {
+	list := []Info{}
+	for _, l := range localEncodings {
+		list = append(list, newInfo(l.names, "", l.e))
+	}
+	for _, names := range ianaNames {
+		if _, ok := encodingsMap[strings.ToLower(names[0])]; ok {
+			continue //overridden by this package
+		}
+		e, err := ianaindex.IANA.Encoding(names[0])
+		if err != nil || e == nil {
+			continue
+		}
+		avail := []string{names[0]}
+		for _, name := range names[1:] {
+			if _, ok := encodingsMap[strings.ToLower(name)]; !ok {
+				avail = append(avail, name)
+			}
+		}
+		mime := ""
+		if name, err := ianaindex.IANA.Name(e); err == nil && name == names[0] {
+			mime, _ = ianaindex.MIME.Name(e)
+		}
+		list = append(list, newInfo(avail, mime, e))
+	}
+	return list
+}
+
+func newInfo(names []string, mime string, e encoding.Encoding) Info {
+	_, eerr := e.NewEncoder().String("a")
+	_, derr := e.NewDecoder().String("a")
+	return Info{
+		Name:    names[0],
+		Aliases: names[1:],
+		MIME:    mime,
+		Encode:  eerr == nil,
+		Decode:  derr == nil,
+	}
+}
+
+//String method is Stringer of Info.
+func (i Info) String() string {
+	var sb strings.Builder
+	sb.WriteString(i.Name)
+	if len(i.MIME) > 0 && i.MIME != i.Name {
+		sb.WriteString(" (MIME: " + i.MIME + ")")
+	}
+	if len(i.Aliases) > 0 {
+		sb.WriteString(": " + strings.Join(i.Aliases, ", "))
+	}
+	switch {
+	case !i.Encode && i.Decode:
+		sb.WriteString(" [decode only]")
+	case i.Encode && !i.Decode:
+		sb.WriteString(" [encode only]")
+	}
+	return sb.String()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
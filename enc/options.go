@@ -0,0 +1,106 @@
+package enc
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+//Options is options of character encoding conversion
+type Options struct {
+	Fallback    Fallback //fallback mode for unencodable characters
+	Subchar     string   //substitution string in FallbackSubchar mode (default: "?")
+	Lenient     bool     //replace invalid byte sequences in source text instead of error
+	Replacement string   //replacement string for invalid byte sequences in lenient mode (default: U+FFFD)
+	Profile     Profile  //vendor mapping profile for Japanese character encodings (not effective to encoding names with profile)
+	FoldKana    bool     //fold half-width katakana into full-width if destination encoding cannot carry it (ISO-2022-JP, ISO-2022-JP-1/-3/-2004)
+}
+
+func (opts *Options) fallback() Fallback {
+	if opts == nil {
+		return FallbackNone
+	}
+	return opts.Fallback
+}
+
+func (opts *Options) subchar() string {
+	if opts == nil {
+		return ""
+	}
+	return opts.Subchar
+}
+
+func (opts *Options) lenient() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.Lenient
+}
+
+func (opts *Options) hasReplacement() bool {
+	return opts != nil && len(opts.Replacement) > 0
+}
+
+func (opts *Options) replacement() string {
+	if opts == nil || len(opts.Replacement) == 0 {
+		return defaultReplacement
+	}
+	return opts.Replacement
+}
+
+func (opts *Options) profile() Profile {
+	if opts == nil {
+		return ProfileNone
+	}
+	return opts.Profile
+}
+
+//withProfile returns encoding.Encoding instance with vendor mapping profile in options.
+func (opts *Options) withProfile(e encoding.Encoding) encoding.Encoding {
+	if _, ok := e.(profileEncoding); ok {
+		return e
+	}
+	return WithProfile(e, opts.profile())
+}
+
+func (opts *Options) foldKana() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.FoldKana
+}
+
+//withFoldKana returns encoding.Encoding instance which folds half-width katakana if FoldKana option is true.
+func (opts *Options) withFoldKana(e encoding.Encoding) encoding.Encoding {
+	if !opts.foldKana() {
+		return e
+	}
+	if e == japanese.ISO2022JP {
+		return cp50220
+	}
+	if v, ok := e.(iso2022JP); ok && v.kana == kanaNone {
+		v.kana = kanaFold
+		return v
+	}
+	return e
+}
+
+//defaultReplacement is replacement string for invalid byte sequences in lenient mode
+const defaultReplacement = "\uFFFD"
+
+//asciiReplacement is replacement string in lenient mode if destination encoding cannot carry U+FFFD
+const asciiReplacement = "?"
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,292 @@
+package enc
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+)
+
+//Profile is type of vendor mapping profile for Japanese character encodings (Shift_JIS, EUC-JP, ISO-2022-JP)
+type Profile int
+
+const (
+	ProfileNone     Profile = iota //mapping of golang.org/x/text package (WHATWG Encoding Standard, default)
+	ProfileJIS                     //JIS X 0208 strict mapping (Java, ICU, and so on)
+	ProfileCP932                   //Microsoft CP932 (Windows-31J) mapping
+	ProfileEUCJPMS                 //eucJP-ms mapping
+	ProfileCP51932                 //Microsoft CP51932 mapping
+)
+
+var profileNamesMap = map[string]Profile{
+	"none":       ProfileNone,
+	"jis-strict": ProfileJIS,
+	"cp932":      ProfileCP932,
+	"eucjp-ms":   ProfileEUCJPMS,
+	"cp51932":    ProfileCP51932,
+}
+
+//troubleRunes is table of the known trouble characters in each profile.
+//Index of each row is JIS X 0208 characters: 1-29 (―), 1-32 (＼), 1-33 (〜), 1-34 (‖), 1-61 (−), 1-81 (¢), 1-82 (£), 2-44 (¬).
+//Character 1-32 is mapped to U+FF3C in all profiles to avoid collision with U+005C (REVERSE SOLIDUS) in ASCII.
+var troubleRunes = map[Profile][]rune{
+	ProfileNone:    {0x2015, 0xff3c, 0xff5e, 0x2225, 0xff0d, 0xffe0, 0xffe1, 0xffe2},
+	ProfileJIS:     {0x2014, 0xff3c, 0x301c, 0x2016, 0x2212, 0x00a2, 0x00a3, 0x00ac},
+	ProfileCP932:   {0x2015, 0xff3c, 0xff5e, 0x2225, 0xff0d, 0xffe0, 0xffe1, 0xffe2},
+	ProfileEUCJPMS: {0x2015, 0xff3c, 0x301c, 0x2016, 0x2212, 0x00a2, 0x00a3, 0x00ac},
+	ProfileCP51932: {0x2015, 0xff3c, 0xff5e, 0x2225, 0xff0d, 0xffe0, 0xffe1, 0xffe2},
+}
+
+func (p Profile) String() string {
+	return profileName(p)
+}
+
+func profileName(p Profile) string {
+	for key, value := range profileNamesMap {
+		if value == p {
+			return key
+		}
+	}
+	return ""
+}
+
+//ProfileList returns list of vendor mapping profile
+func ProfileList() []string {
+	return []string{
+		profileName(ProfileNone),
+		profileName(ProfileJIS),
+		profileName(ProfileCP932),
+		profileName(ProfileEUCJPMS),
+		profileName(ProfileCP51932),
+	}
+}
+
+//ProfileOf returns vendor mapping profile from name string
+func ProfileOf(name string) (Profile, error) {
+	if p, ok := profileNamesMap[strings.ToLower(name)]; ok {
+		return p, nil
+	}
+	return ProfileNone, errs.Wrap(ecode.ErrInvalidProfile, errs.WithContext("name", name))
+}
+
+//toProfile maps character decoded by golang.org/x/text package to the profile.
+func (p Profile) toProfile(r rune) rune {
+	for i, c := range troubleRunes[ProfileNone] {
+		if c == r {
+			return troubleRunes[p][i]
+		}
+	}
+	return r
+}
+
+//fromProfile maps character in the profile to golang.org/x/text package.
+func (p Profile) fromProfile(r rune) rune {
+	for i, c := range troubleRunes[p] {
+		if c == r {
+			return troubleRunes[ProfileNone][i]
+		}
+	}
+	return r
+}
+
+//fromAnyProfile maps character in any profile to golang.org/x/text package.
+func fromAnyProfile(r rune) rune {
+	for _, p := range ProfileList() {
+		profile, _ := ProfileOf(p)
+		if m := profile.fromProfile(r); m != r {
+			return m
+		}
+	}
+	return r
+}
+
+//isNative returns true if the profile is same as golang.org/x/text package.
+func (p Profile) isNative() bool {
+	for i, c := range troubleRunes[ProfileNone] {
+		if troubleRunes[p][i] != c {
+			return false
+		}
+	}
+	return true
+}
+
+//WithProfile returns encoding.Encoding instance with vendor mapping profile.
+//It is effective only for Japanese character encodings (Shift_JIS, EUC-JP, ISO-2022-JP, and CP5022x).
+//Encoder of the instance accepts characters of other profiles too.
+func WithProfile(e encoding.Encoding, p Profile) encoding.Encoding {
+	if pe, ok := e.(profileEncoding); ok {
+		e = pe.base
+	}
+	if !isJapanese(e) || p == ProfileNone {
+		return e
+	}
+	return profileEncoding{base: e, profile: p}
+}
+
+func isJapanese(e encoding.Encoding) bool {
+	if v, ok := e.(iso2022JP); ok {
+		return v.cp932 //JIS X 0208 table of golang.org/x/text package
+	}
+	switch e {
+	case japanese.ShiftJIS, japanese.EUCJP, japanese.ISO2022JP:
+		return true
+	}
+	return false
+}
+
+//profileEncoding is encoding.Encoding with vendor mapping profile
+type profileEncoding struct {
+	base    encoding.Encoding
+	profile Profile
+}
+
+//NewDecoder method is implementation of encoding.Encoding interface.
+func (e profileEncoding) NewDecoder() *encoding.Decoder {
+	return &encoding.Decoder{Transformer: &profileDecoder{decoder: e.base.NewDecoder(), profile: e.profile}}
+}
+
+//NewEncoder method is implementation of encoding.Encoding interface.
+func (e profileEncoding) NewEncoder() *encoding.Encoder {
+	return &encoding.Encoder{Transformer: &profileEncoder{encoder: e.base.NewEncoder(), profile: e.profile}}
+}
+
+//profileDecoder is transform.Transformer for decoding with vendor mapping profile
+type profileDecoder struct {
+	decoder transform.Transformer
+	profile Profile
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *profileDecoder) Reset() {
+	t.decoder.Reset()
+}
+
+//Transform method is implementation of transform.Transformer interface.
+//Mapped characters are never longer than original ones, so it rewrites decoded text in place.
+func (t *profileDecoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	nDst, nSrc, err = t.decoder.Transform(dst, src, atEOF)
+	if t.profile.isNative() {
+		return nDst, nSrc, err
+	}
+	b := dst[:nDst]
+	n := 0
+	for i := 0; i < len(b); {
+		if b[i] < utf8.RuneSelf {
+			b[n] = b[i]
+			i++
+			n++
+			continue
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		if m := t.profile.toProfile(r); m != r {
+			n += utf8.EncodeRune(b[n:], m)
+		} else {
+			n += copy(b[n:], b[i:i+size])
+		}
+		i += size
+	}
+	return n, nSrc, err
+}
+
+//profileEncoder is transform.Transformer for encoding with vendor mapping profile.
+//It accepts the known trouble characters of all profiles, so the profile affects only decoding.
+type profileEncoder struct {
+	encoder transform.Transformer
+	profile Profile
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *profileEncoder) Reset() {
+	t.encoder.Reset()
+}
+
+//Transform method is implementation of transform.Transformer interface.
+//Source text is passed to the encoder as is except for mapped characters, so nSrc points to unencodable character on error.
+func (t *profileEncoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if len(src) == 0 {
+		return t.encoder.Transform(dst, src, atEOF)
+	}
+	for nSrc < len(src) {
+		//find next mapped character
+		i, m, size := nSrc, rune(0), 0
+		for i < len(src) {
+			var r rune
+			r, size = utf8.DecodeRune(src[i:])
+			if m = fromAnyProfile(r); m != r {
+				break
+			}
+			i += size
+		}
+		part, eof := src[nSrc:i], atEOF && i == len(src)
+		if i == nSrc {
+			part, eof = []byte(string(m)), atEOF && i+size == len(src)
+		}
+		n, k, err := t.encoder.Transform(dst[nDst:], part, eof)
+		nDst += n
+		if i == nSrc {
+			if k < len(part) {
+				if err == nil {
+					err = transform.ErrShortDst
+				}
+				return nDst, nSrc, err
+			}
+			nSrc += size
+			continue
+		}
+		nSrc += k
+		if err != nil {
+			return nDst, nSrc, err
+		}
+		if k < len(part) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+	}
+	return nDst, nSrc, nil
+}
+
+//Remap function remaps the known trouble characters in UTF-8 text stream between vendor mapping profiles.
+func Remap(to Profile, writer io.Writer, from Profile, txt io.Reader) error {
+	if to == from {
+		return notConvert(writer, txt)
+	}
+	if _, err := io.Copy(writer, transform.NewReader(txt, remapper(to, from))); err != nil {
+		return errs.Wrap(err, errs.WithContext("to", to.String()), errs.WithContext("from", from.String()))
+	}
+	return nil
+}
+
+//RemapString function remaps the known trouble characters in UTF-8 text string between vendor mapping profiles.
+func RemapString(to, from Profile, txt string) string {
+	if to == from {
+		return txt
+	}
+	s, _, _ := transform.String(remapper(to, from), txt)
+	return s
+}
+
+func remapper(to, from Profile) transform.Transformer {
+	return runes.Map(func(r rune) rune {
+		return to.toProfile(from.fromProfile(r))
+	})
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,114 @@
+package enc
+
+import (
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
+)
+
+//resyncer restores state of stateful decoder after reset.
+//Source text consumed by decoder is committed in order,
+//and the prefix is replayed to the decoder after reset (its output is discarded).
+type resyncer interface {
+	commit(src []byte)
+	prefix() []byte
+	reset()
+}
+
+//newResyncer returns resyncer for stateful decoder of the encoding.
+//It returns nil if the decoder is stateless or not supported.
+func newResyncer(e encoding.Encoding) resyncer {
+	if pe, ok := e.(profileEncoding); ok {
+		return newResyncer(pe.base)
+	}
+	if _, ok := e.(iso2022JP); ok || e == japanese.ISO2022JP {
+		return &iso2022Resyncer{}
+	}
+	for _, bo := range []unicode.Endianness{unicode.BigEndian, unicode.LittleEndian} {
+		for _, bp := range []unicode.BOMPolicy{unicode.IgnoreBOM, unicode.UseBOM, unicode.ExpectBOM} {
+			if e == unicode.UTF16(bo, bp) {
+				return &headResyncer{size: 2}
+			}
+		}
+	}
+	for _, bo := range []utf32.Endianness{utf32.BigEndian, utf32.LittleEndian} {
+		for _, bp := range []utf32.BOMPolicy{utf32.IgnoreBOM, utf32.UseBOM, utf32.ExpectBOM} {
+			if e == utf32.UTF32(bo, bp) {
+				return &headResyncer{size: 4}
+			}
+		}
+	}
+	return nil
+}
+
+//iso2022Resyncer is resyncer for ISO-2022-JP variants.
+//State of decoder is restored by the last escape sequence (designation) and SO character.
+type iso2022Resyncer struct {
+	esc     []byte
+	shifted bool
+}
+
+func (r *iso2022Resyncer) commit(src []byte) {
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case asciiESC:
+			if _, n, _ := designation(src[i:]); n > 0 {
+				r.esc = append(r.esc[:0], src[i:i+n]...)
+				i += n - 1
+			}
+		case asciiSO:
+			r.shifted = true
+		case asciiSI:
+			r.shifted = false
+		}
+	}
+}
+
+func (r *iso2022Resyncer) prefix() []byte {
+	if r.shifted {
+		return append(append([]byte{}, r.esc...), asciiSO)
+	}
+	return r.esc
+}
+
+func (r *iso2022Resyncer) reset() {
+	r.esc = nil
+	r.shifted = false
+}
+
+//headResyncer is resyncer for UTF-16 and UTF-32.
+//State of decoder (byte order by BOM) is restored by the first code unit of the text.
+type headResyncer struct {
+	size int
+	head []byte
+}
+
+func (r *headResyncer) commit(src []byte) {
+	if n := r.size - len(r.head); n > 0 {
+		r.head = append(r.head, src[:min(n, len(src))]...)
+	}
+}
+
+func (r *headResyncer) prefix() []byte {
+	return r.head
+}
+
+func (r *headResyncer) reset() {
+	r.head = nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,457 @@
+package enc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+
+	"github.com/goark/errs"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+//repertoireError is interface of error for unencodable character (golang.org/x/text/encoding/internal.RepertoireError)
+type repertoireError interface {
+	error
+	Replacement() byte
+}
+
+func isRepertoireError(err error) bool {
+	_, ok := err.(repertoireError)
+	return ok
+}
+
+//copyTransform copies text stream through transform.Transformer and flushes it at the end.
+func copyTransform(writer io.Writer, t transform.Transformer, txt io.Reader) error {
+	w := transform.NewWriter(writer, t)
+	if _, err := io.Copy(w, txt); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+//encodeTransformer is transform.Transformer for encoding with fallback mode
+type encodeTransformer struct {
+	encoder  transform.Transformer
+	fallback Fallback
+	subchar  string
+	pending  []byte         //encoded substitution string not written yet
+	count    int            //count of substituted characters
+	pos      position       //current position in source text
+	report   func(*Invalid) //if not nil, report invalid characters and skip them
+}
+
+var _ transform.Transformer = (*encodeTransformer)(nil)
+
+func newEncodeTransformer(encoder transform.Transformer, opts *Options) *encodeTransformer {
+	return &encodeTransformer{encoder: encoder, fallback: opts.fallback(), subchar: opts.subchar()}
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *encodeTransformer) Reset() {
+	t.encoder.Reset()
+	t.pending = nil
+	t.count = 0
+	t.pos = position{}
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *encodeTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for {
+		if len(t.pending) > 0 {
+			n := copy(dst[nDst:], t.pending)
+			nDst += n
+			t.pending = t.pending[n:]
+			if len(t.pending) > 0 {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+		}
+		n, m, terr := t.encoder.Transform(dst[nDst:], src[nSrc:], atEOF)
+		t.pos.forward(m, src[nSrc:nSrc+m])
+		nDst += n
+		nSrc += m
+		if !isRepertoireError(terr) {
+			return nDst, nSrc, terr
+		}
+		r, size := utf8.DecodeRune(src[nSrc:])
+		iv := t.pos.invalid(src[nSrc : nSrc+size])
+		if r != utf8.RuneError || size > 1 {
+			iv.Unencodable = true
+			iv.Rune = r
+		}
+		switch {
+		case t.report != nil:
+			t.report(iv)
+		case t.fallback == FallbackNone:
+			return nDst, nSrc, iv
+		default:
+			b, rerr := t.encodeString(t.fallback.replacement(r, t.subchar))
+			if rerr != nil {
+				return nDst, nSrc, rerr
+			}
+			t.pending = b
+			t.count++
+		}
+		t.pos.forward(size, src[nSrc:nSrc+size])
+		nSrc += size
+	}
+}
+
+//encodeString encodes substitution string by encoder with current state.
+func (t *encodeTransformer) encodeString(s string) ([]byte, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	buf := make([]byte, len(s)*4+16)
+	n, _, err := t.encoder.Transform(buf, []byte(s), false)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+//runeError is byte sequence of U+FFFD in UTF-8
+var runeError = []byte(string(utf8.RuneError))
+
+const (
+	decodeBufferSize = 64   //size of buffer for decoding a character
+	decodeChunkSize  = 4096 //size of buffer for decoding a chunk of text
+)
+
+//decodeTransformer is transform.Transformer for decoding with checking invalid byte sequence.
+//Decoders in golang.org/x/text/encoding package replace invalid byte sequence with U+FFFD silently,
+//so the text is decoded character by character if U+FFFD appears.
+//Stateful decoders (ISO-2022-JP variants, UTF-16 and UTF-32) are restored by resyncer before decoding character by character.
+type decodeTransformer struct {
+	decoder   transform.Transformer
+	encoder   *encodeTransformer //encoder for destination (nil if destination is UTF-8)
+	stateless bool               //true if decoder can be reset at any position
+	sync      resyncer           //restores state of stateful decoder after reset (nil if stateless)
+	legit     []byte             //byte sequence of valid U+FFFD character in source encoding
+	pending   []byte             //decoded (and encoded) text not written yet
+	pos       position           //current position in source text
+	slow      int                //length of source text decoded character by character
+	report    func(*Invalid)     //if not nil, report invalid characters and skip them
+	lenient   bool               //replace invalid byte sequences instead of error
+	repl      []byte             //replacement for invalid byte sequences in lenient mode
+	count     int                //count of replaced invalid byte sequences
+	buf       []byte
+	chunk     []byte
+	out       []byte
+}
+
+var _ transform.Transformer = (*decodeTransformer)(nil)
+
+func newDecodeTransformer(decoder encoding.Encoding, encoder *encodeTransformer, opts *Options) *decodeTransformer {
+	return &decodeTransformer{
+		decoder:   decoder.NewDecoder(),
+		encoder:   encoder,
+		stateless: isStateless(decoder),
+		sync:      newResyncer(decoder),
+		legit:     replacementOf(decoder),
+		lenient:   opts.lenient(),
+		repl:      []byte(opts.replacement()),
+		buf:       make([]byte, decodeBufferSize),
+		chunk:     make([]byte, decodeChunkSize),
+	}
+}
+
+//isStateless returns true if decoder of the encoding has no state between characters.
+func isStateless(e encoding.Encoding) bool {
+	switch e {
+	case unicode.UTF8, japanese.ShiftJIS, japanese.EUCJP, korean.EUCKR, simplifiedchinese.GBK, simplifiedchinese.GB18030, traditionalchinese.Big5:
+		return true
+	}
+	switch e := e.(type) {
+	case *charmap.Charmap, jisx0213Encoding:
+		return true
+	case profileEncoding:
+		return isStateless(e.base)
+	}
+	return false
+}
+
+//replacementOf returns byte sequence of U+FFFD character in the encoding.
+func replacementOf(e encoding.Encoding) []byte {
+	b1, err := e.NewEncoder().Bytes([]byte("\uFFFD"))
+	if err != nil {
+		return nil
+	}
+	b2, err := e.NewEncoder().Bytes([]byte("\uFFFD\uFFFD"))
+	if err != nil || len(b2) <= len(b1) {
+		return nil
+	}
+	return b2[len(b1):] //remove BOM if exists
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *decodeTransformer) Reset() {
+	t.decoder.Reset()
+	if t.encoder != nil {
+		t.encoder.Reset()
+	}
+	if t.sync != nil {
+		t.sync.reset()
+	}
+	t.pending = nil
+	t.pos = position{}
+	t.slow = 0
+	t.count = 0
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *decodeTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for {
+		if len(t.pending) > 0 {
+			n := copy(dst[nDst:], t.pending)
+			nDst += n
+			t.pending = t.pending[n:]
+			if len(t.pending) > 0 {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+		}
+		if nSrc >= len(src) {
+			if atEOF && t.encoder != nil {
+				n, _, eerr := t.encoder.Transform(dst[nDst:], nil, true)
+				return nDst + n, nSrc, eerr
+			}
+			return nDst, nSrc, nil
+		}
+		if (t.stateless || t.sync != nil) && t.slow <= 0 {
+			n, m, ferr := t.fastDecode(dst[nDst:], src[nSrc:], atEOF)
+			if ferr != nil {
+				return nDst, nSrc, ferr
+			}
+			nDst += n
+			nSrc += m
+			if n > 0 || m > 0 {
+				continue
+			}
+		}
+		m, serr := t.slowDecode(src[nSrc:], atEOF)
+		if serr != nil {
+			return nDst, nSrc, serr
+		}
+		nSrc += m
+		t.slow -= m
+	}
+}
+
+//fastDecode decodes a chunk of text if it does not include U+FFFD and unencodable characters.
+func (t *decodeTransformer) fastDecode(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	buf := t.chunk
+	if t.encoder == nil {
+		buf = dst
+	}
+	n, m, _ := t.decoder.Transform(buf, src, atEOF)
+	if m == 0 {
+		return 0, 0, nil
+	}
+	decoded := buf[:n]
+	if bytes.ContainsRune(decoded, utf8.RuneError) {
+		t.resetDecoder()
+		t.slow = m
+		return 0, 0, nil
+	}
+	if t.encoder == nil {
+		t.forward(src[:m], decoded)
+		return n, m, nil
+	}
+	out, k, err := t.encode(decoded)
+	t.pending = out
+	if err == nil {
+		t.forward(src[:m], decoded)
+		return 0, m, nil
+	}
+	var iv *Invalid
+	if !errors.As(err, &iv) {
+		return 0, 0, err
+	}
+	//rewind to unencodable character
+	t.resetDecoder()
+	l, err := t.sourceLength(src[:m], k)
+	if err != nil {
+		return 0, 0, err
+	}
+	t.forward(src[:l], decoded[:k])
+	t.resetDecoder()
+	t.slow = m - l
+	return 0, l, nil
+}
+
+//sourceLength returns length of source text for decoded text of k bytes.
+func (t *decodeTransformer) sourceLength(src []byte, k int) (int, error) {
+	l := 0
+	for k > 0 {
+		n, m, err := t.step(src[l:], true)
+		if err != nil {
+			return l, err
+		}
+		l += m
+		k -= n
+	}
+	return l, nil
+}
+
+//slowDecode decodes a character in source text.
+func (t *decodeTransformer) slowDecode(src []byte, atEOF bool) (int, error) {
+	n, m, err := t.step(src, atEOF)
+	if err != nil {
+		return 0, err
+	}
+	consumed, decoded, text := src[:m], t.buf[:n], t.buf[:n]
+	replaced := false
+	if t.isInvalid(consumed, decoded) {
+		//following characters are decoded again in the next step
+		m, n = t.invalidLength(consumed, decoded)
+		consumed, decoded, text = src[:m], t.buf[:n], t.buf[:n]
+		iv := t.pos.invalid(consumed)
+		switch {
+		case t.report != nil:
+			t.report(iv)
+			t.forward(consumed, text)
+			return m, nil
+		case t.lenient:
+			//decoded text may include valid characters after invalid byte sequence
+			t.count += bytes.Count(decoded, runeError)
+			decoded = bytes.ReplaceAll(decoded, runeError, t.repl)
+			replaced = true
+		default:
+			return 0, iv
+		}
+	}
+	if t.encoder == nil {
+		t.pending = append(t.out[:0], decoded...)
+		t.forward(consumed, text)
+		return m, nil
+	}
+	count := t.encoder.count
+	out, _, err := t.encode(decoded)
+	if replaced {
+		t.encoder.count = count //replacement is counted in lenient mode already
+	}
+	t.pending = out
+	if err != nil {
+		var iv *Invalid
+		if !errors.As(err, &iv) {
+			return 0, err
+		}
+		iv.Offset, iv.Line, iv.Column, iv.Bytes = t.pos.offset, t.pos.line+1, t.pos.column+1, append([]byte{}, consumed...)
+		if t.report == nil {
+			return 0, iv
+		}
+		t.report(iv)
+	}
+	t.forward(consumed, text)
+	return m, nil
+}
+
+//forward moves current position forward by consumed source text.
+func (t *decodeTransformer) forward(consumed, decoded []byte) {
+	t.pos.forward(len(consumed), decoded)
+	if t.sync != nil {
+		t.sync.commit(consumed)
+	}
+}
+
+//resetDecoder resets decoder to the state at current position.
+func (t *decodeTransformer) resetDecoder() {
+	t.decoder.Reset()
+	if t.sync != nil {
+		if p := t.sync.prefix(); len(p) > 0 {
+			_, _, _ = t.decoder.Transform(t.buf, p, false) //output is discarded
+		}
+	}
+}
+
+//step decodes a character in source text to t.buf.
+func (t *decodeTransformer) step(src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for w := 1; ; w++ {
+		eof := false
+		if w >= len(src) {
+			w = len(src)
+			eof = atEOF
+		}
+		nDst, nSrc, err = t.decoder.Transform(t.buf, src[:w], eof)
+		if nDst > 0 || nSrc > 0 {
+			return nDst, nSrc, nil
+		}
+		if !errs.Is(err, transform.ErrShortSrc) || w == len(src) {
+			return nDst, nSrc, err
+		}
+	}
+}
+
+//invalidLength returns length of invalid byte sequence at the beginning of consumed source text, and length of its decoded text.
+//Decoders in golang.org/x/text/encoding package may consume following characters with invalid byte sequence (e.g. LF after leading byte).
+func (t *decodeTransformer) invalidLength(consumed, decoded []byte) (int, int) {
+	i := bytes.Index(decoded, runeError)
+	if !t.stateless || i < 0 || i+len(runeError) == len(decoded) {
+		return len(consumed), len(decoded)
+	}
+	l := i + len(runeError)
+	defer t.decoder.Reset()
+	for k := 1; k < len(consumed); k++ {
+		if t.decodesTo(consumed[:k], decoded[:l]) && t.decodesTo(consumed[k:], decoded[l:]) {
+			return k, l
+		}
+	}
+	return len(consumed), len(decoded)
+}
+
+//decodesTo returns true if the source text is decoded to the text exactly.
+func (t *decodeTransformer) decodesTo(src, txt []byte) bool {
+	buf := make([]byte, decodeBufferSize)
+	t.decoder.Reset()
+	n, m, err := t.decoder.Transform(buf, src, true)
+	return err == nil && m == len(src) && bytes.Equal(buf[:n], txt)
+}
+
+//isInvalid returns true if decoded text includes U+FFFD for invalid byte sequence.
+func (t *decodeTransformer) isInvalid(consumed, decoded []byte) bool {
+	if !bytes.ContainsRune(decoded, utf8.RuneError) {
+		return false
+	}
+	return !bytes.Equal(consumed, t.legit)
+}
+
+//encode encodes decoded text to destination encoding.
+//It returns encoded text and length of consumed decoded text.
+func (t *decodeTransformer) encode(decoded []byte) ([]byte, int, error) {
+	out := t.out[:0]
+	consumed := 0
+	for {
+		if cap(out)-len(out) < decodeBufferSize {
+			out = append(out, make([]byte, decodeChunkSize)...)[:len(out)]
+		}
+		n, m, err := t.encoder.Transform(out[len(out):cap(out)], decoded[consumed:], false)
+		out = out[:len(out)+n]
+		consumed += m
+		if !errs.Is(err, transform.ErrShortDst) {
+			t.out = out
+			return out, consumed, err
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
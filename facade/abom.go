@@ -0,0 +1,113 @@
+package facade
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/rbom"
+	"github.com/goark/gocli/rwi"
+	"github.com/spf13/cobra"
+)
+
+//newAddBomCmd returns cobra.Command instance for show sub-command
+func newAddBomCmd(ui *rwi.RWI) *cobra.Command {
+	abomCmd := &cobra.Command{
+		Use:     "add-bom",
+		Aliases: []string{"abom", "ab"},
+		Short:   "Add BOM character at the beginning of the text",
+		Long:    "Add BOM character at the beginning of the text (UTF-8, UTF-16 or UTF-32).\n Do nothing if the text has BOM of the same form already.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			//Options
+			inp, ferr := cmd.Flags().GetString("file")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --file option", errs.WithCause(ferr)))
+				return
+			}
+			out, ferr := cmd.Flags().GetString("output")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --output option", errs.WithCause(ferr)))
+				return
+			}
+			formName, ferr := cmd.Flags().GetString("encoding-form")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --encoding-form option", errs.WithCause(ferr)))
+				return
+			}
+			form, rerr := rbom.FormOf(formName)
+			if rerr != nil {
+				err = debugPrint(ui, rerr)
+				return
+			}
+
+			//Input stream
+			r := ui.Reader()
+			if len(inp) > 0 {
+				file, ferr := os.Open(filepath.Clean(inp))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("file", inp)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				r = file
+			}
+
+			//Output stream
+			w := ui.Writer()
+			if len(out) > 0 {
+				file, ferr := os.Create(filepath.Clean(out))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("output", out)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				w = file
+			}
+
+			//Run command
+			b, rerr := rbom.AddBom(r, form)
+			if rerr != nil {
+				err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+				return
+			}
+			if _, cerr := io.Copy(w, bytes.NewReader(b)); cerr != nil {
+				err = debugPrint(ui, errs.Wrap(cerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+				return
+			}
+			return
+		},
+	}
+	abomCmd.Flags().StringP("file", "f", "", "path of input text file")
+	_ = abomCmd.MarkFlagFilename("file")
+	abomCmd.Flags().StringP("output", "o", "", "path of output file")
+	_ = abomCmd.MarkFlagFilename("output")
+	abomCmd.Flags().StringP("encoding-form", "e", "utf-8", fmt.Sprintf("encoding form of Unicode: [%s]", strings.Join(rbom.FormList(), "|")))
+	_ = abomCmd.RegisterFlagCompletionFunc("encoding-form", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return rbom.FormList(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return abomCmd
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
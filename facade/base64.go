@@ -1,6 +1,7 @@
 package facade
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -39,6 +40,16 @@ func newBase64Cmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --for-url option", errs.WithCause(ferr)))
 				return
 			}
+			wrap, ferr := cmd.Flags().GetInt("wrap")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --wrap option", errs.WithCause(ferr)))
+				return
+			}
+			ignoreSpace, ferr := cmd.Flags().GetBool("ignore-space")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --ignore-space option", errs.WithCause(ferr)))
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -69,10 +80,11 @@ func newBase64Cmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Run command
+			opts := &b64.Options{LineLength: wrap, IgnoreSpace: ignoreSpace}
 			if decodeFlag {
-				err = b64.Decode(forURL, noPadding, r, w)
+				err = b64.DecodeWithOptions(forURL, noPadding, r, w, opts)
 			} else {
-				err = b64.Encode(forURL, noPadding, r, w)
+				err = b64.EncodeWithOptions(forURL, noPadding, r, w, opts)
 			}
 			if err != nil {
 				return debugPrint(ui, errs.Wrap(err, errs.WithContext("output", out)))
@@ -85,6 +97,8 @@ func newBase64Cmd(ui *rwi.RWI) *cobra.Command {
 	base64Cmd.Flags().BoolP("decode", "d", false, "decode BASE64 string")
 	base64Cmd.Flags().BoolP("no-padding", "p", false, "no padding")
 	base64Cmd.Flags().BoolP("for-url", "u", false, "encoding/decoding defined in RFC 4648")
+	base64Cmd.Flags().IntP("wrap", "w", 0, fmt.Sprintf("wrap encoded lines after N characters (%d for MIME, %d for PEM, 0: no wrapping)", b64.MIMELineLength, b64.PEMLineLength))
+	base64Cmd.Flags().BoolP("ignore-space", "i", false, "ignore whitespace characters (include CR/LF) in decoding")
 
 	return base64Cmd
 }
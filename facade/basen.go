@@ -0,0 +1,109 @@
+package facade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/basen"
+	"github.com/goark/gocli/rwi"
+	"github.com/spf13/cobra"
+)
+
+//newBaseNCmd returns cobra.Command instance for show sub-command
+func newBaseNCmd(ui *rwi.RWI) *cobra.Command {
+	basenCmd := &cobra.Command{
+		Use:     "baseN [flags] [file]",
+		Aliases: []string{"basen", "bn"},
+		Short:   "Encode/Decode Base32, Base58 or hex",
+		Long:    "Encode/Decode Base32 (RFC 4648 standard and extended hex alphabets, Crockford's), Base58 (Bitcoin alphabet) or hex.",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			//Options
+			out, ferr := cmd.Flags().GetString("output")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --output option", errs.WithCause(ferr)))
+				return
+			}
+			decodeFlag, ferr := cmd.Flags().GetBool("decode")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --decode option", errs.WithCause(ferr)))
+				return
+			}
+			name, ferr := cmd.Flags().GetString("alphabet")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --alphabet option", errs.WithCause(ferr)))
+				return
+			}
+			alphabet, aerr := basen.AlphabetOf(name)
+			if aerr != nil {
+				err = debugPrint(ui, aerr)
+				return
+			}
+
+			//Input stream
+			r := ui.Reader()
+			if len(args) > 0 {
+				file, ferr := os.Open(filepath.Clean(args[0]))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("file", args[0])))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				r = file
+			}
+
+			//Output stream
+			w := ui.Writer()
+			if len(out) > 0 {
+				file, ferr := os.Create(filepath.Clean(out))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("output", out)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				w = file
+			}
+
+			//Run command
+			if decodeFlag {
+				err = basen.Decode(alphabet, r, w)
+			} else {
+				err = basen.Encode(alphabet, r, w)
+			}
+			if err != nil {
+				return debugPrint(ui, errs.Wrap(err, errs.WithContext("output", out)))
+			}
+			return nil
+		},
+	}
+	basenCmd.Flags().StringP("output", "o", "", "path of output file")
+	_ = basenCmd.MarkFlagFilename("output")
+	basenCmd.Flags().BoolP("decode", "d", false, "decode baseN string")
+	basenCmd.Flags().StringP("alphabet", "a", "base32", fmt.Sprintf("alphabet (encoding scheme): [%s]", strings.Join(basen.AlphabetList(), "|")))
+	_ = basenCmd.RegisterFlagCompletionFunc("alphabet", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return basen.AlphabetList(), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return basenCmd
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,77 @@
+package facade
+
+import (
+	"io"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+	"github.com/goark/gnkf/guess"
+	"github.com/goark/gocli/rwi"
+	"github.com/spf13/cobra"
+)
+
+//binaryMode is behavior for binary (not text) input
+type binaryMode struct {
+	skip bool //skip binary input without output
+	fail bool //exit with error if input is binary
+}
+
+//setBinaryFlags sets --skip-binary and --fail-on-binary options to the command.
+func setBinaryFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("skip-binary", "", false, "skip binary (not text) input without output")
+	cmd.Flags().BoolP("fail-on-binary", "", false, "exit with error if input is binary (not text)")
+}
+
+//getBinaryMode returns behavior for binary input from --skip-binary and --fail-on-binary options.
+//It returns error if both options are specified.
+func getBinaryMode(cmd *cobra.Command) (binaryMode, error) {
+	skip, err := cmd.Flags().GetBool("skip-binary")
+	if err != nil {
+		return binaryMode{}, errs.New("Error in --skip-binary option", errs.WithCause(err))
+	}
+	fail, err := cmd.Flags().GetBool("fail-on-binary")
+	if err != nil {
+		return binaryMode{}, errs.New("Error in --fail-on-binary option", errs.WithCause(err))
+	}
+	if skip && fail {
+		return binaryMode{}, errs.Wrap(ecode.ErrExclusiveOptions, errs.WithContext("options", "--skip-binary, --fail-on-binary"))
+	}
+	return binaryMode{skip: skip, fail: fail}, nil
+}
+
+//check checks whether the input stream is binary data.
+//It returns io.Reader instance for reading whole input, and true if the input is skipped.
+func (m binaryMode) check(ui *rwi.RWI, r io.Reader, path string) (io.Reader, bool, error) {
+	if !m.skip && !m.fail {
+		return r, false, nil
+	}
+	bin, rest, err := guess.IsBinaryReader(r, &guess.Options{SampleSize: guess.DefaultSampleSize})
+	if err != nil {
+		return rest, false, errs.Wrap(err, errs.WithContext("file", path))
+	}
+	if !bin {
+		return rest, false, nil
+	}
+	if m.fail {
+		return rest, false, errs.Wrap(ecode.ErrBinaryData, errs.WithContext("file", path))
+	}
+	if len(path) > 0 {
+		return rest, true, ui.OutputErrln("skip binary input:", path)
+	}
+	return rest, true, ui.OutputErrln("skip binary input")
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,12 +1,14 @@
 package facade
 
 import (
-	"bytes"
-	"io"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
 	"github.com/goark/gnkf/enc"
 	"github.com/goark/gnkf/guess"
 	"github.com/goark/gnkf/rbom"
@@ -53,11 +55,114 @@ func newEncCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --guess option", errs.WithCause(ferr)))
 				return
 			}
+			gsName, ferr := cmd.Flags().GetString("guess-strategy")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --guess-strategy option", errs.WithCause(ferr)))
+				return
+			}
+			gs, gerr := guess.StrategyOf(gsName)
+			if gerr != nil {
+				err = debugPrint(ui, gerr)
+				return
+			}
+			gsSize, ferr := cmd.Flags().GetInt64("guess-sample-size")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --guess-sample-size option", errs.WithCause(ferr)))
+				return
+			}
 			rbFlag, ferr := cmd.Flags().GetBool("remove-bom")
 			if ferr != nil {
 				err = debugPrint(ui, errs.New("Error in --remove-bom option", errs.WithCause(ferr)))
 				return
 			}
+			rbAllFlag, ferr := cmd.Flags().GetBool("remove-all-bom")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --remove-all-bom option", errs.WithCause(ferr)))
+				return
+			}
+			fbName, ferr := cmd.Flags().GetString("fallback")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --fallback option", errs.WithCause(ferr)))
+				return
+			}
+			fb, eerr := enc.FallbackOf(fbName)
+			if eerr != nil {
+				err = debugPrint(ui, eerr)
+				return
+			}
+			subchar, ferr := cmd.Flags().GetString("subchar")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --subchar option", errs.WithCause(ferr)))
+				return
+			}
+			lenientFlag, ferr := cmd.Flags().GetBool("lenient")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --lenient option", errs.WithCause(ferr)))
+				return
+			}
+			repl, ferr := cmd.Flags().GetString("replacement")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --replacement option", errs.WithCause(ferr)))
+				return
+			}
+			pfName, ferr := cmd.Flags().GetString("profile")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --profile option", errs.WithCause(ferr)))
+				return
+			}
+			pf, eerr := enc.ProfileOf(pfName)
+			if eerr != nil {
+				err = debugPrint(ui, eerr)
+				return
+			}
+			foldFlag, ferr := cmd.Flags().GetBool("fold-kana")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --fold-kana option", errs.WithCause(ferr)))
+				return
+			}
+			reportFlag, ferr := cmd.Flags().GetBool("report-invalid")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --report-invalid option", errs.WithCause(ferr)))
+				return
+			}
+			if reportFlag && len(out) > 0 {
+				err = debugPrint(ui, errs.Wrap(ecode.ErrExclusiveOptions, errs.WithContext("options", "--report-invalid, --output")))
+				return
+			}
+
+			listFlag, ferr := cmd.Flags().GetBool("list")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --list option", errs.WithCause(ferr)))
+				return
+			}
+			jsonFlag, ferr := cmd.Flags().GetBool("json")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --json option", errs.WithCause(ferr)))
+				return
+			}
+
+			//List of encodings
+			if listFlag {
+				list := enc.List()
+				if jsonFlag {
+					if err = json.NewEncoder(ui.Writer()).Encode(list); err != nil {
+						err = debugPrint(ui, errs.Wrap(err))
+					}
+					return
+				}
+				for _, info := range list {
+					if err = ui.Outputln(info); err != nil {
+						err = debugPrint(ui, errs.Wrap(err))
+						return
+					}
+				}
+				return
+			}
+			binMode, berr := getBinaryMode(cmd)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -71,17 +176,26 @@ func newEncCmd(ui *rwi.RWI) *cobra.Command {
 				}()
 				r = file
 			}
+
+			//Check binary input
+			r, skip, berr := binMode.check(ui, r, inp)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
+			if skip {
+				return
+			}
 			if flagGuess {
-				dup := &bytes.Buffer{}
-				ss, eerr := guess.Encoding(io.TeeReader(r, dup))
+				res, rest, eerr := guess.DetectReader(r, &guess.Options{Strategy: gs, SampleSize: gsSize})
 				if eerr != nil {
 					err = debugPrint(ui, errs.Wrap(eerr, errs.WithContext("file", inp)))
 					return
 				}
-				if len(ss) > 0 {
-					from = ss[0]
+				if len(res) > 0 {
+					from = res[0].Charset
 				}
-				r = dup
+				r = rest
 			}
 
 			//Output stream
@@ -99,27 +213,45 @@ func newEncCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Remove BOM
-			if rbFlag {
+			if rbFlag || rbAllFlag {
 				e, eerr := enc.Encoding(from)
 				if eerr != nil {
 					err = debugPrint(ui, errs.Wrap(eerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
 					return
 				}
 				if e == unicode.UTF8 {
-					b, rerr := rbom.RemoveBom(r)
-					if rerr != nil {
-						err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+					r = rbom.NewReaderWithOptions(r, &rbom.Options{All: rbAllFlag})
+				}
+			}
+
+			//Report invalid characters
+			if reportFlag {
+				list, eerr := enc.FindInvalid(to, from, r)
+				if eerr != nil {
+					err = debugPrint(ui, errs.Wrap(eerr, errs.WithContext("file", inp)))
+					return
+				}
+				for _, iv := range list {
+					if err = ui.Outputln(iv); err != nil {
+						err = debugPrint(ui, errs.Wrap(err, errs.WithContext("file", inp)))
 						return
 					}
-					r = bytes.NewReader(b)
 				}
+				if len(list) > 0 {
+					err = debugPrint(ui, errs.Wrap(ecode.ErrInvalidEncoding, errs.WithContext("count", len(list)), errs.WithContext("file", inp)))
+				}
+				return
 			}
 
 			//Run command
-			if eerr := enc.Convert(to, w, from, r); eerr != nil {
+			count, eerr := enc.ConvertWithOptions(to, w, from, r, &enc.Options{Fallback: fb, Subchar: subchar, Lenient: lenientFlag, Replacement: repl, Profile: pf, FoldKana: foldFlag})
+			if eerr != nil {
 				err = debugPrint(ui, errs.Wrap(eerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
 				return
 			}
+			if count > 0 {
+				err = ui.OutputErrln("substituted characters:", count)
+			}
 			return
 		},
 	}
@@ -130,7 +262,29 @@ func newEncCmd(ui *rwi.RWI) *cobra.Command {
 	encCmd.Flags().StringP("src-encoding", "s", "utf-8", "character encoding name of source text")
 	encCmd.Flags().StringP("dst-encoding", "d", "utf-8", "character encoding name of output text")
 	encCmd.Flags().BoolP("guess", "g", false, "guess character encoding of source text")
-	encCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character in source text (UTF-8 only)")
+	encCmd.Flags().StringP("guess-strategy", "", "chardet", fmt.Sprintf("strategy for guessing character encoding: [%s]", strings.Join(guess.StrategyList(), "|")))
+	_ = encCmd.RegisterFlagCompletionFunc("guess-strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return guess.StrategyList(), cobra.ShellCompDirectiveNoFileComp
+	})
+	encCmd.Flags().Int64P("guess-sample-size", "", guess.DefaultSampleSize, "maximum size of sample for guessing in bytes (0: whole text)")
+	encCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character at the beginning of source text (UTF-8 only)")
+	encCmd.Flags().BoolP("remove-all-bom", "", false, "remove all U+FEFF characters (zero width no-break space) in the text")
+	encCmd.Flags().StringP("fallback", "", "none", fmt.Sprintf("fallback mode for unencodable characters: [%s]", strings.Join(enc.FallbackList(), "|")))
+	_ = encCmd.RegisterFlagCompletionFunc("fallback", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return enc.FallbackList(), cobra.ShellCompDirectiveNoFileComp
+	})
+	encCmd.Flags().StringP("subchar", "", "?", "substitution string for unencodable characters (with --fallback subchar)")
+	encCmd.Flags().BoolP("lenient", "", false, "replace invalid byte sequences in source text instead of error")
+	encCmd.Flags().StringP("replacement", "", "", "replacement string for invalid byte sequences (with --lenient option, default U+FFFD or '?' if unencodable)")
+	encCmd.Flags().StringP("profile", "", "none", fmt.Sprintf("vendor mapping profile for Japanese encodings: [%s]", strings.Join(enc.ProfileList(), "|")))
+	_ = encCmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return enc.ProfileList(), cobra.ShellCompDirectiveNoFileComp
+	})
+	encCmd.Flags().BoolP("fold-kana", "", false, "fold half-width katakana into full-width if output encoding cannot carry it (ISO-2022-JP family)")
+	encCmd.Flags().BoolP("report-invalid", "", false, "report all invalid characters in source text instead of converting")
+	encCmd.Flags().BoolP("list", "l", false, "list supported character encodings with aliases")
+	encCmd.Flags().BoolP("json", "j", false, "output list of character encodings in JSON format (with --list option)")
+	setBinaryFlags(encCmd)
 
 	return encCmd
 }
@@ -46,7 +46,11 @@ func newRootCmd(ui *rwi.RWI, args []string) *cobra.Command {
 		newWidthCmd(ui),
 		newKanaCmd(ui),
 		newBase64Cmd(ui),
+		newBaseNCmd(ui),
+		newPEMCmd(ui),
 		newRemoveBomCmd(ui),
+		newAddBomCmd(ui),
+		newRepairCmd(ui),
 		newCompletionCmd(ui),
 		newhashCmd(ui),
 		newBCryptCmd(ui),
@@ -1,9 +1,10 @@
 package facade
 
 import (
-	"os"
-	"path/filepath"
+	"encoding/json"
+	"fmt"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/ecode"
@@ -15,10 +16,10 @@ import (
 // newGuessCmd returns cobra.Command instance for show sub-command
 func newGuessCmd(ui *rwi.RWI) *cobra.Command {
 	guessCmd := &cobra.Command{
-		Use:     "guess",
+		Use:     "guess [file...]",
 		Aliases: []string{"g"},
 		Short:   "Guess character encoding of the text",
-		Long:    "Guess character encoding of the text",
+		Long:    "Guess character encoding of the text.\n If files or directories are given as arguments, guess each file (batch mode).",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			//Options
 			path, ferr := cmd.Flags().GetString("file")
@@ -31,35 +32,94 @@ func newGuessCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --all option", errs.WithCause(ferr)))
 				return
 			}
+			jsonFlag, ferr := cmd.Flags().GetBool("json")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --json option", errs.WithCause(ferr)))
+				return
+			}
+			sName, ferr := cmd.Flags().GetString("strategy")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --strategy option", errs.WithCause(ferr)))
+				return
+			}
+			strategy, gerr := guess.StrategyOf(sName)
+			if gerr != nil {
+				err = debugPrint(ui, gerr)
+				return
+			}
+			sampleSize, ferr := cmd.Flags().GetInt64("sample-size")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --sample-size option", errs.WithCause(ferr)))
+				return
+			}
+			recursive, ferr := cmd.Flags().GetBool("recursive")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --recursive option", errs.WithCause(ferr)))
+				return
+			}
+			opts := &guess.Options{Strategy: strategy, SampleSize: sampleSize}
 
-			//Input stream
-			r := ui.Reader()
-			if len(path) > 0 {
-				file, ferr := os.Open(filepath.Clean(path))
-				if ferr != nil {
-					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("file", path)))
-					return
+			//Batch mode
+			if len(args) > 0 {
+				paths := args
+				if len(path) > 0 {
+					paths = append([]string{path}, args...)
+				}
+				entries := guessFiles(paths, recursive, opts)
+				failed := 0
+				for _, e := range entries {
+					if len(e.Error) > 0 {
+						failed++
+					}
+					if !flagAll && len(e.Guesses) > 1 {
+						e.Guesses = e.Guesses[:1]
+					}
+				}
+				if jsonFlag {
+					err = json.NewEncoder(ui.Writer()).Encode(entries)
+				} else {
+					for _, e := range entries {
+						if err = ui.Outputln(e); err != nil {
+							break
+						}
+					}
+				}
+				if err == nil && failed > 0 {
+					err = errs.Wrap(ecode.ErrCannotDetect, errs.WithContext("failed", failed))
 				}
-				defer func() {
-					err = errs.Join(err, file.Close())
-				}()
-				r = file
+				err = debugPrint(ui, errs.Wrap(err))
+				return
 			}
 
 			//Run command
-			ss, gerr := guess.Encoding(r)
+			var res []guess.Result
+			if len(path) > 0 {
+				res, gerr = guess.DetectFile(path, opts)
+			} else {
+				res, gerr = guess.DetectWithOptions(ui.Reader(), opts)
+			}
 			if gerr != nil {
 				err = debugPrint(ui, errs.Wrap(gerr, errs.WithContext("file", path)))
 				return
 			}
-			if len(ss) == 0 {
+			if len(res) == 0 {
 				err = debugPrint(ui, errs.Wrap(ecode.ErrNoData, errs.WithContext("file", path)))
 				return
 			}
-			if flagAll {
-				err = ui.Outputln(strings.Join(ss, "\n"))
-			} else {
-				err = ui.Outputln(ss[0])
+			switch {
+			case flagAll && jsonFlag:
+				err = json.NewEncoder(ui.Writer()).Encode(res)
+			case jsonFlag:
+				err = json.NewEncoder(ui.Writer()).Encode(res[0])
+			case flagAll:
+				tw := tabwriter.NewWriter(ui.Writer(), 0, 8, 2, ' ', 0)
+				fmt.Fprintln(tw, "CHARSET\tCONFIDENCE\tBOM\tLANGUAGE")
+				for _, r := range res {
+					fmt.Fprintf(tw, "%s\t%d\t%v\t%s\n", r.Charset, r.Confidence, r.BOM, r.Language)
+				}
+				err = tw.Flush()
+			default:
+				err = ui.Outputln(res[0].Charset)
 			}
 			err = debugPrint(ui, errs.Wrap(err))
 			return
@@ -67,7 +127,14 @@ func newGuessCmd(ui *rwi.RWI) *cobra.Command {
 	}
 	guessCmd.Flags().StringP("file", "f", "", "path of input text file")
 	_ = guessCmd.MarkFlagFilename("file")
-	guessCmd.Flags().BoolP("all", "", false, "print all guesses")
+	guessCmd.Flags().BoolP("all", "", false, "print all guesses with confidence and language")
+	guessCmd.Flags().BoolP("json", "j", false, "output guesses in JSON format")
+	guessCmd.Flags().StringP("strategy", "", "chardet", fmt.Sprintf("strategy for guessing character encoding: [%s]", strings.Join(guess.StrategyList(), "|")))
+	_ = guessCmd.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return guess.StrategyList(), cobra.ShellCompDirectiveNoFileComp
+	})
+	guessCmd.Flags().BoolP("recursive", "r", false, "guess files in directories recursively (batch mode)")
+	guessCmd.Flags().Int64P("sample-size", "", guess.DefaultSampleSize, "maximum size of sample for guessing in bytes (0: whole text)")
 
 	return guessCmd
 }
@@ -0,0 +1,111 @@
+package facade
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goark/gnkf/guess"
+	"github.com/goark/gnkf/newline"
+)
+
+//guessEntry is result of guessing character encoding of a file in batch mode
+type guessEntry struct {
+	Path    string         `json:"path"`
+	Binary  bool           `json:"binary"`
+	Newline string         `json:"newline,omitempty"` //dominant newline form ("none" if no newline, "mixed" if mixed newlines)
+	Guesses []guess.Result `json:"guesses,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+//String method is Stringer for guessEntry.
+func (e *guessEntry) String() string {
+	switch {
+	case len(e.Error) > 0:
+		return e.Path + ": error: " + e.Error
+	case e.Binary:
+		return e.Path + ": binary"
+	case len(e.Guesses) == 0:
+		return e.Path + ": unknown"
+	}
+	r := e.Guesses[0]
+	return fmt.Sprintf("%s: %s (confidence: %d, newline: %s, bom: %v)", e.Path, r.Charset, r.Confidence, e.Newline, r.BOM)
+}
+
+//guessFiles guesses character encoding of files (and files in directories if recursive is true).
+func guessFiles(paths []string, recursive bool, opts *guess.Options) []*guessEntry {
+	entries := []*guessEntry{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		switch {
+		case err != nil:
+			entries = append(entries, &guessEntry{Path: path, Error: err.Error()})
+		case !info.IsDir():
+			entries = append(entries, guessFile(path, opts))
+		case !recursive:
+			entries = append(entries, &guessEntry{Path: path, Error: "is a directory (use --recursive option)"})
+		default:
+			_ = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					entries = append(entries, &guessEntry{Path: p, Error: err.Error()})
+				} else if d.Type().IsRegular() {
+					entries = append(entries, guessFile(p, opts))
+				}
+				return nil
+			})
+		}
+	}
+	return entries
+}
+
+//guessFile guesses character encoding and newline form of a file.
+//The file is read only once as the sample (head, middle and tail).
+func guessFile(path string, opts *guess.Options) *guessEntry {
+	e := &guessEntry{Path: path}
+	sample, err := guess.SampleFile(path, opts.SampleSize)
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	if e.Binary = guess.IsBinary(sample); e.Binary {
+		return e
+	}
+	if e.Guesses, err = guess.DetectBytesWithOptions(sample, opts); err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	if len(e.Guesses) > 0 && (strings.HasPrefix(e.Guesses[0].Charset, "UTF-16") || strings.HasPrefix(e.Guesses[0].Charset, "UTF-32")) {
+		return e //newlines are not counted in UTF-16 and UTF-32
+	}
+	res, err := newline.Detect(bytes.NewReader(sample))
+	if err != nil {
+		e.Error = err.Error()
+		return e
+	}
+	e.Newline = "none"
+	if f, ok := res.Dominant(); ok {
+		e.Newline = f.String()
+		if res.Mixed() {
+			e.Newline = "mixed"
+		}
+	}
+	return e
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
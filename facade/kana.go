@@ -1,7 +1,6 @@
 package facade
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -58,6 +57,16 @@ func newKanaCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --remove-bom option", errs.WithCause(ferr)))
 				return
 			}
+			rbAllFlag, ferr := cmd.Flags().GetBool("remove-all-bom")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --remove-all-bom option", errs.WithCause(ferr)))
+				return
+			}
+			binMode, berr := getBinaryMode(cmd)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -73,6 +82,16 @@ func newKanaCmd(ui *rwi.RWI) *cobra.Command {
 				r = file
 			}
 
+			//Check binary input
+			r, skip, berr := binMode.check(ui, r, inp)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
+			if skip {
+				return
+			}
+
 			//Output stream
 			w := ui.Writer()
 			if len(out) > 0 {
@@ -88,13 +107,8 @@ func newKanaCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Remove BOM
-			if rbFlag {
-				b, rerr := rbom.RemoveBom(r)
-				if rerr != nil {
-					err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
-					return
-				}
-				r = bytes.NewReader(b)
+			if rbFlag || rbAllFlag {
+				r = rbom.NewReaderWithOptions(r, &rbom.Options{All: rbAllFlag})
 			}
 
 			//Run command
@@ -114,7 +128,9 @@ func newKanaCmd(ui *rwi.RWI) *cobra.Command {
 		return newline.FormList(), cobra.ShellCompDirectiveNoFileComp
 	})
 	kanaCmd.Flags().BoolP("fold", "", false, "convert character width by fold form")
-	kanaCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character")
+	kanaCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character at the beginning of the text")
+	kanaCmd.Flags().BoolP("remove-all-bom", "", false, "remove all U+FEFF characters (zero width no-break space) in the text")
+	setBinaryFlags(kanaCmd)
 
 	return kanaCmd
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
 	"github.com/goark/gnkf/newline"
 	"github.com/goark/gocli/rwi"
 	"github.com/spf13/cobra"
@@ -36,6 +37,36 @@ func newNwlnCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --newline-form option", errs.WithCause(ferr)))
 				return
 			}
+			unicodeFlag, ferr := cmd.Flags().GetBool("unicode")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --unicode option", errs.WithCause(ferr)))
+				return
+			}
+			finalFlag, ferr := cmd.Flags().GetBool("final-newline")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --final-newline option", errs.WithCause(ferr)))
+				return
+			}
+			trimFlag, ferr := cmd.Flags().GetBool("trim-space")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --trim-space option", errs.WithCause(ferr)))
+				return
+			}
+			collapseFlag, ferr := cmd.Flags().GetBool("collapse-blank-lines")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --collapse-blank-lines option", errs.WithCause(ferr)))
+				return
+			}
+			detectFlag, ferr := cmd.Flags().GetBool("detect")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --detect option", errs.WithCause(ferr)))
+				return
+			}
+			binMode, berr := getBinaryMode(cmd)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -51,6 +82,33 @@ func newNwlnCmd(ui *rwi.RWI) *cobra.Command {
 				r = file
 			}
 
+			//Check binary input
+			r, skip, berr := binMode.check(ui, r, inp)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
+			if skip {
+				return
+			}
+
+			//Detect newlines
+			if detectFlag {
+				res, nerr := newline.Detect(r)
+				if nerr != nil {
+					err = debugPrint(ui, errs.Wrap(nerr, errs.WithContext("file", inp)))
+					return
+				}
+				if err = ui.Outputln(res); err != nil {
+					err = debugPrint(ui, errs.Wrap(err, errs.WithContext("file", inp)))
+					return
+				}
+				if res.Mixed() {
+					err = debugPrint(ui, errs.Wrap(ecode.ErrMixedNewlines, errs.WithContext("file", inp), errs.WithContext("result", res.String())))
+				}
+				return
+			}
+
 			//Output stream
 			w := ui.Writer()
 			if len(out) > 0 {
@@ -66,7 +124,12 @@ func newNwlnCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Run command
-			if nerr := newline.Convert(form, w, r); nerr != nil {
+			if nerr := newline.ConvertWithOptions(form, w, r, &newline.Options{
+				Unicode:            unicodeFlag,
+				FinalNewline:       finalFlag,
+				TrimSpace:          trimFlag,
+				CollapseBlankLines: collapseFlag,
+			}); nerr != nil {
 				err = debugPrint(ui, errs.Wrap(nerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
 				return
 			}
@@ -81,6 +144,12 @@ func newNwlnCmd(ui *rwi.RWI) *cobra.Command {
 	_ = nwlnCmd.RegisterFlagCompletionFunc("newline-form", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return newline.FormList(), cobra.ShellCompDirectiveNoFileComp
 	})
+	nwlnCmd.Flags().BoolP("unicode", "u", false, "treat Unicode line terminators (NEL, LS, PS, VT, FF) as newlines")
+	nwlnCmd.Flags().BoolP("final-newline", "", false, "ensure exactly one newline at the end of the text")
+	nwlnCmd.Flags().BoolP("trim-space", "", false, "strip trailing whitespaces in each line")
+	nwlnCmd.Flags().BoolP("collapse-blank-lines", "", false, "collapse runs of blank lines into one blank line")
+	nwlnCmd.Flags().BoolP("detect", "", false, "detect newlines in the text (error if mixed newlines)")
+	setBinaryFlags(nwlnCmd)
 
 	return nwlnCmd
 }
@@ -1,7 +1,6 @@
 package facade
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -46,6 +45,17 @@ func newNormCmd(ui *rwi.RWI) *cobra.Command {
 			rbFlag, ferr := cmd.Flags().GetBool("remove-bom")
 			if ferr != nil {
 				err = debugPrint(ui, errs.New("Error in --remove-bom option", errs.WithCause(ferr)))
+				return
+			}
+			rbAllFlag, ferr := cmd.Flags().GetBool("remove-all-bom")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --remove-all-bom option", errs.WithCause(ferr)))
+				return
+			}
+			binMode, berr := getBinaryMode(cmd)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
 			}
 
 			//Input stream
@@ -62,6 +72,16 @@ func newNormCmd(ui *rwi.RWI) *cobra.Command {
 				r = file
 			}
 
+			//Check binary input
+			r, skip, berr := binMode.check(ui, r, inp)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
+			if skip {
+				return
+			}
+
 			//Output stream
 			w := ui.Writer()
 			if len(out) > 0 {
@@ -77,13 +97,8 @@ func newNormCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Remove BOM
-			if rbFlag {
-				b, rerr := rbom.RemoveBom(r)
-				if rerr != nil {
-					err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
-					return
-				}
-				r = bytes.NewReader(b)
+			if rbFlag || rbAllFlag {
+				r = rbom.NewReaderWithOptions(r, &rbom.Options{All: rbAllFlag})
 			}
 
 			//Run command
@@ -103,7 +118,9 @@ func newNormCmd(ui *rwi.RWI) *cobra.Command {
 		return nrm.FormList(), cobra.ShellCompDirectiveDefault
 	})
 	normCmd.Flags().BoolP("kangxi-radicals", "k", false, "normalize kangxi radicals only (with nfkc or nfkd form)")
-	normCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character")
+	normCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character at the beginning of the text")
+	normCmd.Flags().BoolP("remove-all-bom", "", false, "remove all U+FEFF characters (zero width no-break space) in the text")
+	setBinaryFlags(normCmd)
 
 	return normCmd
 }
@@ -0,0 +1,204 @@
+package facade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+	"github.com/goark/gnkf/pem"
+	"github.com/goark/gocli/rwi"
+	"github.com/spf13/cobra"
+)
+
+var descriptionPEM = `Encode/Decode PEM (RFC 7468) armor.
+ Wrap data in "-----BEGIN <TYPE>-----" block with headers,
+ or extract and decode one or all blocks from PEM bundle.`
+
+//newPEMCmd returns cobra.Command instance for show sub-command
+func newPEMCmd(ui *rwi.RWI) *cobra.Command {
+	pemCmd := &cobra.Command{
+		Use:   "pem",
+		Short: "Encode/Decode PEM armor",
+		Long:  descriptionPEM,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			//Options
+			inp, ferr := cmd.Flags().GetString("file")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --file option", errs.WithCause(ferr)))
+				return
+			}
+			out, ferr := cmd.Flags().GetString("output")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --output option", errs.WithCause(ferr)))
+				return
+			}
+			decodeFlag, ferr := cmd.Flags().GetBool("decode")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --decode option", errs.WithCause(ferr)))
+				return
+			}
+			blockType, ferr := cmd.Flags().GetString("type")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --type option", errs.WithCause(ferr)))
+				return
+			}
+			hlist, ferr := cmd.Flags().GetStringArray("header")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --header option", errs.WithCause(ferr)))
+				return
+			}
+			headers := map[string]string{}
+			for _, h := range hlist {
+				k, v, ok := strings.Cut(h, ":")
+				if !ok {
+					err = debugPrint(ui, errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithContext("header", h)))
+					return
+				}
+				headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+			}
+			allFlag, ferr := cmd.Flags().GetBool("all")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --all option", errs.WithCause(ferr)))
+				return
+			}
+			index, ferr := cmd.Flags().GetInt("index")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --index option", errs.WithCause(ferr)))
+				return
+			}
+			if allFlag && cmd.Flags().Changed("index") {
+				err = debugPrint(ui, errs.Wrap(ecode.ErrExclusiveOptions, errs.WithContext("options", "--all, --index")))
+				return
+			}
+			extractFlag, ferr := cmd.Flags().GetBool("extract")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --extract option", errs.WithCause(ferr)))
+				return
+			}
+			listFlag, ferr := cmd.Flags().GetBool("list")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --list option", errs.WithCause(ferr)))
+				return
+			}
+			modes := []string{}
+			for _, m := range []struct {
+				name string
+				flag bool
+			}{{"--decode", decodeFlag}, {"--extract", extractFlag}, {"--list", listFlag}} {
+				if m.flag {
+					modes = append(modes, m.name)
+				}
+			}
+			if len(modes) > 1 {
+				err = debugPrint(ui, errs.Wrap(ecode.ErrExclusiveOptions, errs.WithContext("options", strings.Join(modes, ", "))))
+				return
+			}
+
+			//Input stream
+			r := ui.Reader()
+			if len(inp) > 0 {
+				file, ferr := os.Open(filepath.Clean(inp))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("file", inp)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				r = file
+			}
+
+			//Output stream
+			w := ui.Writer()
+			if len(out) > 0 {
+				file, ferr := os.Create(filepath.Clean(out))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("output", out)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				w = file
+			}
+
+			//Encode
+			if !decodeFlag && !extractFlag && !listFlag {
+				if perr := pem.Encode(blockType, headers, r, w); perr != nil {
+					err = debugPrint(ui, errs.Wrap(perr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+				}
+				return
+			}
+
+			//Decode
+			blocks, perr := pem.Decode(r)
+			if perr != nil {
+				err = debugPrint(ui, errs.Wrap(perr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+				return
+			}
+			selected := make([]*pem.Block, 0, len(blocks))
+			for _, b := range blocks {
+				if len(blockType) == 0 || b.Type == blockType {
+					selected = append(selected, b)
+				}
+			}
+			if len(selected) == 0 {
+				err = debugPrint(ui, errs.Wrap(ecode.ErrNoPEMBlock, errs.WithContext("type", blockType)))
+				return
+			}
+			if !allFlag && !listFlag {
+				if index < 0 || index >= len(selected) {
+					err = debugPrint(ui, errs.Wrap(ecode.ErrNoPEMBlock, errs.WithContext("type", blockType), errs.WithContext("index", index)))
+					return
+				}
+				selected = selected[index : index+1]
+			}
+			for i, b := range selected {
+				switch {
+				case listFlag:
+					_, perr = fmt.Fprintf(w, "%d: %s (%d bytes, %d headers)\n", i, b.Type, len(b.Bytes), len(b.Headers))
+				case extractFlag:
+					perr = pem.EncodeBlock(b, w)
+				default:
+					_, perr = w.Write(b.Bytes)
+				}
+				if perr != nil {
+					err = debugPrint(ui, errs.Wrap(perr, errs.WithContext("output", out)))
+					return
+				}
+			}
+			return
+		},
+	}
+	pemCmd.Flags().StringP("file", "f", "", "path of input file")
+	_ = pemCmd.MarkFlagFilename("file")
+	pemCmd.Flags().StringP("output", "o", "", "path of output file")
+	_ = pemCmd.MarkFlagFilename("output")
+	pemCmd.Flags().BoolP("decode", "d", false, "decode PEM block")
+	pemCmd.Flags().StringP("type", "t", "", "type of PEM block (filter of blocks in decoding)")
+	pemCmd.Flags().StringArrayP("header", "H", nil, "header of PEM block in \"key: value\" form (repeatable)")
+	pemCmd.Flags().BoolP("all", "a", false, "decode or extract all blocks (with --decode or --extract option)")
+	pemCmd.Flags().IntP("index", "n", 0, "index of PEM block to decode or extract (see --list option)")
+	pemCmd.Flags().BoolP("extract", "x", false, "extract PEM block (re-encoded in canonical form) instead of decoding")
+	pemCmd.Flags().BoolP("list", "l", false, "list PEM blocks in the text")
+
+	return pemCmd
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
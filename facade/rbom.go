@@ -1,7 +1,6 @@
 package facade
 
 import (
-	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -18,7 +17,7 @@ func newRemoveBomCmd(ui *rwi.RWI) *cobra.Command {
 		Use:     "remove-bom",
 		Aliases: []string{"rbom", "rb"},
 		Short:   "Remove BOM character in UTF-8 string",
-		Long:    "Remove BOM character in UTF-8 string.",
+		Long:    "Remove BOM character in UTF-8 string.\n BOM at the beginning of UTF-16 or UTF-32 text is also removed.\n U+FEFF (zero width no-break space) inside the text is kept unless --all option is specified.",
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			//Options
 			inp, ferr := cmd.Flags().GetString("file")
@@ -31,6 +30,11 @@ func newRemoveBomCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --output option", errs.WithCause(ferr)))
 				return
 			}
+			allFlag, ferr := cmd.Flags().GetBool("all")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --all option", errs.WithCause(ferr)))
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -61,12 +65,7 @@ func newRemoveBomCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Run command
-			b, rerr := rbom.RemoveBom(r)
-			if rerr != nil {
-				err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
-				return
-			}
-			if _, cerr := io.Copy(w, bytes.NewReader(b)); cerr != nil {
+			if _, cerr := io.Copy(w, rbom.NewReaderWithOptions(r, &rbom.Options{All: allFlag})); cerr != nil {
 				err = debugPrint(ui, errs.Wrap(cerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
 				return
 			}
@@ -77,6 +76,7 @@ func newRemoveBomCmd(ui *rwi.RWI) *cobra.Command {
 	_ = rbomCmd.MarkFlagFilename("file")
 	rbomCmd.Flags().StringP("output", "o", "", "path of output file")
 	_ = rbomCmd.MarkFlagFilename("output")
+	rbomCmd.Flags().BoolP("all", "a", false, "remove all U+FEFF characters (zero width no-break space) in UTF-8 text")
 
 	return rbomCmd
 }
@@ -0,0 +1,137 @@
+package facade
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/repair"
+	"github.com/goark/gocli/rwi"
+	"github.com/spf13/cobra"
+)
+
+var descriptionRepair = `Repair mojibake in the text (UTF-8 encoding only).
+ Mojibake is text wrongly decoded as other character encoding and re-encoded in UTF-8.
+ Tries reverse conversions by candidate character encodings, and takes the most plausible result.`
+
+//newRepairCmd returns cobra.Command instance for show sub-command
+func newRepairCmd(ui *rwi.RWI) *cobra.Command {
+	repairCmd := &cobra.Command{
+		Use:     "repair",
+		Aliases: []string{"rep"},
+		Short:   "Repair mojibake in the text",
+		Long:    descriptionRepair,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			//Options
+			inp, ferr := cmd.Flags().GetString("file")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --file option", errs.WithCause(ferr)))
+				return
+			}
+			out, ferr := cmd.Flags().GetString("output")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --output option", errs.WithCause(ferr)))
+				return
+			}
+			depth, ferr := cmd.Flags().GetInt("max-depth")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --max-depth option", errs.WithCause(ferr)))
+				return
+			}
+			charsets, ferr := cmd.Flags().GetStringSlice("charsets")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --charsets option", errs.WithCause(ferr)))
+				return
+			}
+			reportFlag, ferr := cmd.Flags().GetBool("report")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --report option", errs.WithCause(ferr)))
+				return
+			}
+			jsonFlag, ferr := cmd.Flags().GetBool("json")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --json option", errs.WithCause(ferr)))
+				return
+			}
+
+			//Input stream
+			r := ui.Reader()
+			if len(inp) > 0 {
+				file, ferr := os.Open(filepath.Clean(inp))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("file", inp)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				r = file
+			}
+
+			//Output stream
+			w := ui.Writer()
+			if reportFlag {
+				w = io.Discard
+			} else if len(out) > 0 {
+				file, ferr := os.Create(filepath.Clean(out))
+				if ferr != nil {
+					err = debugPrint(ui, errs.Wrap(ferr, errs.WithContext("output", out)))
+					return
+				}
+				defer func() {
+					err = errs.Join(err, file.Close())
+				}()
+				w = file
+			}
+
+			//Run command
+			res, rerr := repair.Repair(w, r, &repair.Options{MaxDepth: depth, Charsets: charsets})
+			if rerr != nil {
+				err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
+				return
+			}
+			if reportFlag {
+				if jsonFlag {
+					if err = json.NewEncoder(ui.Writer()).Encode(res); err != nil {
+						err = debugPrint(ui, errs.Wrap(err))
+					}
+					return
+				}
+				if err = ui.Outputln(res); err != nil {
+					err = debugPrint(ui, errs.Wrap(err))
+				}
+			}
+			return
+		},
+	}
+	repairCmd.Flags().StringP("file", "f", "", "path of input text file")
+	_ = repairCmd.MarkFlagFilename("file")
+	repairCmd.Flags().StringP("output", "o", "", "path of output file")
+	_ = repairCmd.MarkFlagFilename("output")
+	repairCmd.Flags().IntP("max-depth", "", repair.DefaultMaxDepth, "maximum depth of mojibake chain")
+	repairCmd.Flags().StringSliceP("charsets", "", repair.DefaultCharsets, "candidates of character encoding wrongly used for decoding")
+	_ = repairCmd.RegisterFlagCompletionFunc("charsets", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return repair.DefaultCharsets, cobra.ShellCompDirectiveNoFileComp
+	})
+	repairCmd.Flags().BoolP("report", "r", false, "report likely chain of mojibake instead of repaired text")
+	repairCmd.Flags().BoolP("json", "j", false, "output report in JSON format (with --report option)")
+
+	return repairCmd
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
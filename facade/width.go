@@ -1,7 +1,6 @@
 package facade
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -43,6 +42,16 @@ func newWidthCmd(ui *rwi.RWI) *cobra.Command {
 				err = debugPrint(ui, errs.New("Error in --remove-bom option", errs.WithCause(ferr)))
 				return
 			}
+			rbAllFlag, ferr := cmd.Flags().GetBool("remove-all-bom")
+			if ferr != nil {
+				err = debugPrint(ui, errs.New("Error in --remove-all-bom option", errs.WithCause(ferr)))
+				return
+			}
+			binMode, berr := getBinaryMode(cmd)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
 
 			//Input stream
 			r := ui.Reader()
@@ -58,6 +67,16 @@ func newWidthCmd(ui *rwi.RWI) *cobra.Command {
 				r = file
 			}
 
+			//Check binary input
+			r, skip, berr := binMode.check(ui, r, inp)
+			if berr != nil {
+				err = debugPrint(ui, berr)
+				return
+			}
+			if skip {
+				return
+			}
+
 			//Output stream
 			w := ui.Writer()
 			if len(out) > 0 {
@@ -73,13 +92,8 @@ func newWidthCmd(ui *rwi.RWI) *cobra.Command {
 			}
 
 			//Remove BOM
-			if rbFlag {
-				b, rerr := rbom.RemoveBom(r)
-				if rerr != nil {
-					err = debugPrint(ui, errs.Wrap(rerr, errs.WithContext("file", inp), errs.WithContext("output", out)))
-					return
-				}
-				r = bytes.NewReader(b)
+			if rbFlag || rbAllFlag {
+				r = rbom.NewReaderWithOptions(r, &rbom.Options{All: rbAllFlag})
 			}
 
 			//Run command
@@ -98,12 +112,14 @@ func newWidthCmd(ui *rwi.RWI) *cobra.Command {
 	_ = widthCmd.RegisterFlagCompletionFunc("conversion-form", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return width.FormList(), cobra.ShellCompDirectiveDefault
 	})
-	widthCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character")
+	widthCmd.Flags().BoolP("remove-bom", "b", false, "remove BOM character at the beginning of the text")
+	widthCmd.Flags().BoolP("remove-all-bom", "", false, "remove all U+FEFF characters (zero width no-break space) in the text")
+	setBinaryFlags(widthCmd)
 
 	return widthCmd
 }
 
-/* Copyright 2020-2021 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -0,0 +1,176 @@
+package guess
+
+import (
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+//binaryMagics is list of magic numbers of binary file formats.
+var binaryMagics = [][]byte{
+	[]byte("\x89PNG\r\n\x1a\n"),   //PNG
+	[]byte("GIF87a"),              //GIF
+	[]byte("GIF89a"),              //GIF
+	{0xff, 0xd8, 0xff},            //JPEG
+	[]byte("%PDF-"),               //PDF
+	[]byte("PK\x03\x04"),          //ZIP (and OOXML, JAR, EPUB, ...)
+	[]byte("PK\x05\x06"),          //ZIP (empty archive)
+	{0x1f, 0x8b},                  //gzip
+	{0xfd, '7', 'z', 'X', 'Z', 0}, //xz
+	{0x28, 0xb5, 0x2f, 0xfd},      //Zstandard
+	[]byte("7z\xbc\xaf\x27\x1c"),  //7-Zip
+	[]byte("Rar!\x1a\x07"),        //RAR
+	[]byte("\x7fELF"),             //ELF
+	{0xca, 0xfe, 0xba, 0xbe},      //Java class, Mach-O fat binary
+	{0xcf, 0xfa, 0xed, 0xfe},      //Mach-O (64bit)
+	[]byte("\x00asm"),             //WebAssembly
+	[]byte("SQLite format 3\x00"), //SQLite
+	[]byte("OggS\x00\x02"),        //Ogg (version 0, beginning of stream)
+}
+
+//containerMagics is list of magic numbers of container formats with sub-type.
+//Short ASCII magic numbers are not enough to tell binary from text.
+var containerMagics = []struct {
+	magic  []byte   //magic number at the beginning of data
+	offset int      //offset of sub-type
+	types  [][]byte //list of sub-types
+}{
+	{magic: []byte("RIFF"), offset: 8, types: [][]byte{[]byte("WAVE"), []byte("AVI "), []byte("WEBP")}},           //WAV, AVI, WebP (RIFF + size + type)
+	{magic: []byte("wOFF"), offset: 4, types: [][]byte{{0x00, 0x01, 0x00, 0x00}, []byte("OTTO"), []byte("true")}}, //WOFF (signature + flavor)
+	{magic: []byte("wOF2"), offset: 4, types: [][]byte{{0x00, 0x01, 0x00, 0x00}, []byte("OTTO"), []byte("true")}}, //WOFF2 (signature + flavor)
+}
+
+//maxControlRatio is maximum ratio of control characters in text.
+const maxControlRatio = 0.1
+
+//IsBinary reports whether the data is binary (not text),
+//by magic numbers of file formats, NUL bytes and ratio of control characters.
+//Text starting with BOM (including UTF-16 and UTF-32) is not binary,
+//and NUL bytes in UTF-16 and UTF-32 text without BOM are found by pattern of code units.
+func IsBinary(b []byte) bool {
+	if _, ok := detectBOM(b); ok {
+		return false
+	}
+	for _, magic := range binaryMagics {
+		if bytes.HasPrefix(b, magic) {
+			return true
+		}
+	}
+	for _, c := range containerMagics {
+		if !bytes.HasPrefix(b, c.magic) || len(b) < c.offset {
+			continue
+		}
+		for _, typ := range c.types {
+			if bytes.HasPrefix(b[c.offset:], typ) {
+				return true
+			}
+		}
+	}
+	if size, _ := wideText(b); size > 0 {
+		return false
+	}
+	ctrl := 0
+	for _, c := range b {
+		switch {
+		case c == 0:
+			return true
+		case c == '\t' || c == '\n' || c == '\v' || c == '\f' || c == '\r' || c == 0x1b: //ESC is used in ISO-2022-JP
+		case c < 0x20 || c == 0x7f:
+			ctrl++
+		}
+	}
+	return len(b) > 0 && float64(ctrl)/float64(len(b)) > maxControlRatio
+}
+
+//wideText returns size of code unit (2 or 4) and byte order (true if big endian) of UTF-16 or UTF-32 text without BOM.
+//The text must consist of whole code units and contain NUL bytes in upper bytes of code units (e.g. ASCII characters), but no NUL characters and few control characters.
+//It returns 0 if the data is not such text.
+func wideText(b []byte) (int, bool) {
+	for _, size := range []int{4, 2} {
+		for _, be := range []bool{true, false} {
+			if isWideText(b, size, be) {
+				return size, be
+			}
+		}
+	}
+	return 0, false
+}
+
+func isWideText(b []byte, size int, be bool) bool {
+	n := len(b) / size
+	if n == 0 || len(b)%size != 0 {
+		return false
+	}
+	upper, ctrl := 0, 0
+	for i := 0; i < n; i++ {
+		u := codeUnit(b[i*size:(i+1)*size], be)
+		switch {
+		case u == 0, u > unicode.MaxRune:
+			return false
+		case size == 4 && utf16.IsSurrogate(u):
+			return false
+		case size == 2 && isHighSurrogate(u): //high surrogate must be followed by low surrogate
+			if i+1 < n {
+				if l := codeUnit(b[(i+1)*size:(i+2)*size], be); !isLowSurrogate(l) {
+					return false
+				}
+				i++
+			}
+			continue
+		case size == 2 && isLowSurrogate(u): //lone low surrogate
+			return false
+		case u == '\t' || u == '\n' || u == '\v' || u == '\f' || u == '\r' || u == 0x1b:
+		case u < 0x20 || u == 0x7f:
+			ctrl++
+		}
+		if u < 0x100 {
+			upper++
+		}
+	}
+	return upper > 0 && float64(ctrl)/float64(n) <= maxControlRatio
+}
+
+//codeUnit returns code unit of UTF-16 or UTF-32 in the byte order.
+func codeUnit(b []byte, be bool) rune {
+	u := rune(0)
+	for i := range b {
+		c := b[i]
+		if !be {
+			c = b[len(b)-1-i]
+		}
+		u = u<<8 | rune(c)
+	}
+	return u
+}
+
+//IsBinaryReader reports whether the byte stream is binary (not text), by the sample at the beginning of the stream.
+//It returns io.Reader instance for reading whole data (the sample and the remainder of the stream).
+func IsBinaryReader(txt io.Reader, opts *Options) (bool, io.Reader, error) {
+	if txt == nil {
+		return false, nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	b, err := readSample(txt, opts.sampleSize())
+	if err != nil {
+		return false, nil, errs.Wrap(err)
+	}
+	return IsBinary(b), io.MultiReader(bytes.NewReader(b), txt), nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
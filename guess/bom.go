@@ -0,0 +1,40 @@
+package guess
+
+import "bytes"
+
+//boms is list of byte order marks (BOM), UTF-32 first because BOM of UTF-32LE starts with BOM of UTF-16LE.
+var boms = []struct {
+	bom     []byte
+	charset string
+}{
+	{bom: []byte{0x00, 0x00, 0xfe, 0xff}, charset: "UTF-32BE"},
+	{bom: []byte{0xff, 0xfe, 0x00, 0x00}, charset: "UTF-32LE"},
+	{bom: []byte{0xef, 0xbb, 0xbf}, charset: "UTF-8"},
+	{bom: []byte{0xfe, 0xff}, charset: "UTF-16BE"},
+	{bom: []byte{0xff, 0xfe}, charset: "UTF-16LE"},
+}
+
+//detectBOM guesses character encoding by BOM at the beginning of text.
+func detectBOM(b []byte) (Result, bool) {
+	for _, m := range boms {
+		if bytes.HasPrefix(b, m.bom) {
+			return Result{Charset: m.charset, Confidence: 100, BOM: true}, true
+		}
+	}
+	return Result{}, false
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -29,7 +29,24 @@ func ExampleEncodingBytes() {
 	//UTF-8,windows-1252,windows-1253,Shift_JIS,windows-1255
 }
 
-/* Copyright 2020 Spiegel
+func ExampleDetect() {
+	res, err := guess.Detect(strings.NewReader("こんにちは，世界！\n私の名前は Spiegel です。"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, r := range res {
+		fmt.Println(r)
+	}
+	//Output:
+	//UTF-8 (confidence: 100)
+	//windows-1252 (confidence: 14, language: no)
+	//windows-1253 (confidence: 13, language: el)
+	//Shift_JIS (confidence: 10, language: ja)
+	//windows-1255 (confidence: 7, language: he)
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
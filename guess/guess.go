@@ -3,6 +3,8 @@ package guess
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/goark/errs"
@@ -24,6 +26,108 @@ func Encoding(txt io.Reader) ([]string, error) {
 
 //EncodingBytes detects guesses of character encoding name from byte array
 func EncodingBytes(b []byte) ([]string, error) {
+	all, err := DetectBytes(b)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	ss := []string{}
+	for _, r := range all {
+		ss = append(ss, r.Charset)
+	}
+	return ss, nil
+}
+
+//Detect detects guesses of character encoding from byte stream, with confidence and language.
+//Results are sorted by confidence (descending order).
+func Detect(txt io.Reader) ([]Result, error) {
+	return DetectWithOptions(txt, nil)
+}
+
+//DetectWithOptions detects guesses of character encoding from byte stream with options.
+//Only the sample at the beginning of the stream is read if Options.SampleSize is set.
+func DetectWithOptions(txt io.Reader, opts *Options) ([]Result, error) {
+	if txt == nil {
+		return nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	b, err := readSample(txt, opts.sampleSize())
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return DetectBytesWithOptions(b, opts)
+}
+
+//DetectReader detects guesses of character encoding from the sample at the beginning of byte stream.
+//It returns io.Reader instance for reading whole text (the sample and the remainder of the stream).
+func DetectReader(txt io.Reader, opts *Options) ([]Result, io.Reader, error) {
+	if txt == nil {
+		return nil, nil, errs.Wrap(ecode.ErrNullPointer)
+	}
+	b, err := readSample(txt, opts.sampleSize())
+	if err != nil {
+		return nil, nil, errs.Wrap(err)
+	}
+	rest := io.MultiReader(bytes.NewReader(b), txt)
+	res, err := DetectBytesWithOptions(b, opts)
+	if err != nil {
+		return nil, rest, errs.Wrap(err)
+	}
+	return res, rest, nil
+}
+
+//DetectFile detects guesses of character encoding of the file.
+//Head, middle and tail of the file are read as the sample if Options.SampleSize is set.
+func DetectFile(path string, opts *Options) ([]Result, error) {
+	b, err := SampleFile(path, opts.sampleSize())
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return DetectBytesWithOptions(b, opts)
+}
+
+//SampleFile reads head, middle and tail of the file as the sample for guessing (whole file if n <= 0).
+//Only the beginning of the file is read if the file is not a regular file.
+func SampleFile(path string, n int64) (b []byte, err error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("path", path))
+	}
+	defer func() {
+		err = errs.Join(err, file.Close())
+	}()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("path", path))
+	}
+	if !info.Mode().IsRegular() {
+		b, err = readSample(file, n)
+	} else {
+		b, err = sampleAt(file, info.Size(), n)
+	}
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("path", path))
+	}
+	return b, nil
+}
+
+//DetectBytes detects guesses of character encoding from byte array, with confidence and language.
+//Results are sorted by confidence (descending order).
+func DetectBytes(b []byte) ([]Result, error) {
+	return DetectBytesWithOptions(b, nil)
+}
+
+//DetectBytesWithOptions detects guesses of character encoding from byte array with options.
+//If the text starts with BOM, the result is only the encoding of the BOM.
+func DetectBytesWithOptions(b []byte, opts *Options) ([]Result, error) {
+	if r, ok := detectBOM(b); ok {
+		return []Result{r}, nil
+	}
+	if opts.strategy() == StrategyJapanese {
+		res := detectJapanese(b)
+		if len(res) == 0 {
+			return nil, errs.Wrap(ecode.ErrCannotDetect, errs.WithContext("strategy", opts.strategy().String()))
+		}
+		return res, nil
+	}
 	all, err := chardet.NewTextDetector().DetectAll(b)
 	if err != nil {
 		return nil, errs.Wrap(ecode.ErrCannotDetect, errs.WithCause(err))
@@ -34,14 +138,14 @@ func EncodingBytes(b []byte) ([]string, error) {
 		}
 		return all[i].Charset < all[j].Charset
 	})
-	ss := []string{}
+	res := make([]Result, 0, len(all))
 	for _, r := range all {
-		ss = append(ss, r.Charset)
+		res = append(res, Result{Charset: r.Charset, Confidence: r.Confidence, Language: r.Language})
 	}
-	return ss, nil
+	return res, nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
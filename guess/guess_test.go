@@ -2,12 +2,18 @@ package guess
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"os"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/ecode"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
 )
 
 var (
@@ -53,14 +59,334 @@ func TestEncoding(t *testing.T) {
 	}
 }
 
+func TestDetectBytes(t *testing.T) {
+	testCases := []struct {
+		text []byte
+		res  Result
+		str  string
+		err  error
+	}{
+		{text: textUTF8, res: Result{Charset: "UTF-8", Confidence: 100}, str: "UTF-8 (confidence: 100)", err: nil},
+		{text: textSJIS, res: Result{Charset: "Shift_JIS", Confidence: 100, Language: "ja"}, str: "Shift_JIS (confidence: 100, language: ja)", err: nil},
+		{text: textEUC, res: Result{Charset: "EUC-JP", Confidence: 100, Language: "ja"}, str: "EUC-JP (confidence: 100, language: ja)", err: nil},
+		{text: []byte{0xff}, res: Result{}, err: ecode.ErrCannotDetect},
+	}
+	for _, tc := range testCases {
+		res, err := DetectBytes(tc.text)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("DetectBytes() error = \"%+v\", want \"%+v\".", err, tc.err)
+		}
+		if err != nil {
+			continue
+		}
+		for i := 1; i < len(res); i++ {
+			if res[i-1].Confidence < res[i].Confidence {
+				t.Errorf("DetectBytes() = %v, not sorted by confidence.", res)
+			}
+		}
+		if len(res) == 0 || res[0] != tc.res {
+			t.Errorf("DetectBytes() = %v, want %v first.", res, tc.res)
+		} else if res[0].String() != tc.str {
+			t.Errorf("Result.String() = \"%v\", want \"%v\".", res[0].String(), tc.str)
+		}
+	}
+}
+
+func TestDetectJapanese(t *testing.T) {
+	testCases := []struct {
+		text []byte
+		res  []Result
+		err  error
+	}{
+		{text: textUTF8, res: []Result{{Charset: "UTF-8", Confidence: 100, Language: "ja"}}, err: nil},
+		{text: textSJIS, res: []Result{{Charset: "Shift_JIS", Confidence: 100, Language: "ja"}}, err: nil},
+		{text: textEUC, res: []Result{{Charset: "EUC-JP", Confidence: 100, Language: "ja"}}, err: nil},
+		{text: []byte{0x83, 0x65, 0x83, 0x58, 0x83, 0x67}, res: []Result{{Charset: "Shift_JIS", Confidence: 100, Language: "ja"}}, err: nil},                                                      //"テスト" in Shift_JIS
+		{text: []byte{0xa5, 0xc6, 0xa5, 0xb9, 0xa5, 0xc8}, res: []Result{{Charset: "EUC-JP", Confidence: 100, Language: "ja"}, {Charset: "Shift_JIS", Confidence: 50, Language: "ja"}}, err: nil}, //"テスト" in EUC-JP
+		{text: []byte{0x1b, 0x24, 0x42, 0x25, 0x46, 0x25, 0x39, 0x25, 0x48, 0x1b, 0x28, 0x42}, res: []Result{{Charset: "ISO-2022-JP", Confidence: 100, Language: "ja"}}, err: nil},                //"テスト" in ISO-2022-JP
+		{text: []byte("\x1b$(Q\x2e\x21\x1b(B"), res: []Result{{Charset: "ISO-2022-JP-2004", Confidence: 100, Language: "ja"}}, err: nil},
+		{text: []byte{0x87, 0x40, 0x82, 0xa0}, res: []Result{{Charset: "Windows-31J", Confidence: 65, Language: "ja"}}, err: nil}, //"①あ" in CP932
+
+		{text: []byte{0xb1, 0xb2, 0xb3, 0xb4, 0xb5}, res: []Result{{Charset: "Shift_JIS", Confidence: 80, Language: "ja"}, {Charset: "EUC-JP", Confidence: 33, Language: "ja"}}, err: nil},                               //"ｱｲｳｴｵ" in Shift_JIS
+		{text: []byte{0x8e, 0xb1, 0x8e, 0xb2, 0x8e, 0xb3, 0x8e, 0xb4, 0x8e, 0xb5}, res: []Result{{Charset: "EUC-JP", Confidence: 57, Language: "ja"}, {Charset: "Shift_JIS", Confidence: 43, Language: "ja"}}, err: nil}, //"ｱｲｳｴｵ" in EUC-JP (ambiguous)
+		{text: []byte{0xb6, 0xc0, 0xb6, 0xc5}, res: []Result{{Charset: "EUC-JP", Confidence: 56, Language: "ja"}, {Charset: "Shift_JIS", Confidence: 44, Language: "ja"}}, err: nil},                                     //"ｶﾀｶﾅ" in Shift_JIS (ambiguous)
+
+		{text: []byte("hello"), res: []Result{{Charset: "UTF-8", Confidence: 100}}, err: nil},
+		{text: nil, res: []Result{{Charset: "UTF-8", Confidence: 100}}, err: nil},
+		{text: []byte{0xff}, res: nil, err: ecode.ErrCannotDetect},
+	}
+	for _, tc := range testCases {
+		res, err := DetectBytesWithOptions(tc.text, &Options{Strategy: StrategyJapanese})
+		if !errs.Is(err, tc.err) {
+			t.Errorf("DetectBytesWithOptions(% x) error = \"%+v\", want \"%+v\".", tc.text, err, tc.err)
+		}
+		if len(res) < len(tc.res) {
+			t.Errorf("DetectBytesWithOptions(% x) = %v, want %v.", tc.text, res, tc.res)
+			continue
+		}
+		for i, r := range tc.res {
+			if res[i] != r {
+				t.Errorf("DetectBytesWithOptions(% x) = %v, want %v.", tc.text, res, tc.res)
+				break
+			}
+		}
+	}
+}
+
+func TestDetectBOM(t *testing.T) {
+	testCases := []struct {
+		text []byte
+		res  Result
+		str  string
+	}{
+		{text: append([]byte{0xef, 0xbb, 0xbf}, textUTF8...), res: Result{Charset: "UTF-8", Confidence: 100, BOM: true}, str: "UTF-8 (confidence: 100, with BOM)"},
+		{text: []byte{0xff, 0xfe, 0x42, 0x30}, res: Result{Charset: "UTF-16LE", Confidence: 100, BOM: true}, str: "UTF-16LE (confidence: 100, with BOM)"},
+		{text: []byte{0xfe, 0xff, 0x30, 0x42}, res: Result{Charset: "UTF-16BE", Confidence: 100, BOM: true}, str: "UTF-16BE (confidence: 100, with BOM)"},
+		{text: []byte{0xff, 0xfe, 0x00, 0x00, 0x42, 0x30, 0x00, 0x00}, res: Result{Charset: "UTF-32LE", Confidence: 100, BOM: true}, str: "UTF-32LE (confidence: 100, with BOM)"},
+		{text: []byte{0x00, 0x00, 0xfe, 0xff, 0x00, 0x00, 0x30, 0x42}, res: Result{Charset: "UTF-32BE", Confidence: 100, BOM: true}, str: "UTF-32BE (confidence: 100, with BOM)"},
+	}
+	for _, tc := range testCases {
+		for _, opts := range []*Options{nil, {Strategy: StrategyJapanese}} {
+			res, err := DetectBytesWithOptions(tc.text, opts)
+			if err != nil {
+				t.Errorf("DetectBytesWithOptions(% x) error = \"%+v\", want nil.", tc.text, err)
+			} else if len(res) != 1 || res[0] != tc.res {
+				t.Errorf("DetectBytesWithOptions(% x) = %v, want [%v].", tc.text, res, tc.res)
+			} else if res[0].String() != tc.str {
+				t.Errorf("Result.String() = \"%v\", want \"%v\".", res[0].String(), tc.str)
+			}
+		}
+	}
+}
+
+func TestDetectReader(t *testing.T) {
+	text := bytes.Repeat(textSJIS, 100)
+	for _, size := range []int64{0, 16, 1024, int64(len(text)) + 1} {
+		res, r, err := DetectReader(bytes.NewReader(text), &Options{Strategy: StrategyJapanese, SampleSize: size})
+		if err != nil {
+			t.Errorf("DetectReader(%d) error = \"%+v\", want nil.", size, err)
+			continue
+		}
+		if len(res) == 0 || res[0].Charset != "Shift_JIS" {
+			t.Errorf("DetectReader(%d) = %v, want Shift_JIS.", size, res)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Errorf("DetectReader(%d) error = \"%+v\", want nil.", size, err)
+		} else if !bytes.Equal(b, text) {
+			t.Errorf("DetectReader(%d) returns reader of %d bytes, want %d bytes.", size, len(b), len(text))
+		}
+	}
+}
+
+func TestSampleAt(t *testing.T) {
+	lines := []string{}
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, fmt.Sprintf("line %03d あいうえお", i))
+	}
+	text := []byte(strings.Join(lines, "\n") + "\n")
+	for _, size := range []int64{0, 300, 3000, int64(len(text))} {
+		b, err := sampleAt(bytes.NewReader(text), int64(len(text)), size)
+		if err != nil {
+			t.Errorf("sampleAt(%d) error = \"%+v\", want nil.", size, err)
+			continue
+		}
+		if size == 0 || size >= int64(len(text)) {
+			if !bytes.Equal(b, text) {
+				t.Errorf("sampleAt(%d) = %d bytes, want whole text.", size, len(b))
+			}
+			continue
+		}
+		if int64(len(b)) > size {
+			t.Errorf("sampleAt(%d) = %d bytes, want less than or equal to %d bytes.", size, len(b), size)
+		}
+		if !bytes.HasPrefix(b, []byte("line 000 ")) || !bytes.HasSuffix(b, []byte("line 999 あいうえお\n")) || !bytes.Contains(b, []byte("\nline 5")) {
+			t.Errorf("sampleAt(%d) = %q, want head, middle and tail of text.", size, b)
+		}
+		if res, err := DetectBytesWithOptions(b, &Options{Strategy: StrategyJapanese}); err != nil {
+			t.Errorf("DetectBytesWithOptions() error = \"%+v\", want nil.", err)
+		} else if res[0].Charset != "UTF-8" || res[0].Confidence != 100 {
+			t.Errorf("DetectBytesWithOptions() = %v, want UTF-8 (confidence: 100).", res)
+		}
+	}
+	//text without newlines
+	text = []byte("a" + strings.Repeat("あいうえお", 1000))
+	for _, size := range []int64{100, 301, 3002} {
+		b, err := sampleAt(bytes.NewReader(text), int64(len(text)), size)
+		if err != nil {
+			t.Errorf("sampleAt(%d) error = \"%+v\", want nil.", size, err)
+			continue
+		}
+		if int64(len(b)) > size {
+			t.Errorf("sampleAt(%d) = %d bytes, want less than or equal to %d bytes.", size, len(b), size)
+		}
+		if !utf8.Valid(b) {
+			t.Errorf("sampleAt(%d) = %q, want valid UTF-8 text.", size, b)
+		}
+		if !bytes.HasSuffix(b, []byte("えお")) {
+			t.Errorf("sampleAt(%d) = %q, want tail of text.", size, b)
+		}
+	}
+	//UTF-16 and UTF-32 text
+	for _, tc := range []struct {
+		e   encoding.Encoding
+		bom bool
+	}{
+		{e: unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), bom: false},
+		{e: unicode.UTF16(unicode.BigEndian, unicode.UseBOM), bom: true},
+		{e: utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM), bom: false},
+		{e: utf32.UTF32(utf32.BigEndian, utf32.UseBOM), bom: true},
+	} {
+		for _, s := range []string{strings.Join(lines, "\n") + "\n", "a" + strings.Repeat("あい🍣", 1000)} {
+			text, err := tc.e.NewEncoder().Bytes([]byte(s))
+			if err != nil {
+				t.Errorf("Encoder.Bytes() error = \"%+v\", want nil.", err)
+				continue
+			}
+			for _, size := range []int64{301, 3001} {
+				b, err := sampleAt(bytes.NewReader(text), int64(len(text)), size)
+				if err != nil {
+					t.Errorf("sampleAt(%d) error = \"%+v\", want nil.", size, err)
+					continue
+				}
+				if int64(len(b)) > size {
+					t.Errorf("sampleAt(%d) = %d bytes, want less than or equal to %d bytes.", size, len(b), size)
+				}
+				if !bytes.HasSuffix(text, b[len(b)-8:]) {
+					t.Errorf("sampleAt(%d) = %q, want tail of text.", size, b)
+				}
+				d, err := tc.e.NewDecoder().Bytes(b)
+				if err != nil || bytes.ContainsRune(d, utf8.RuneError) {
+					t.Errorf("sampleAt(%d) = %q, want valid text in %v (bom: %v).", size, b, tc.e, tc.bom)
+				}
+			}
+		}
+	}
+}
+
+func TestDetectFile(t *testing.T) {
+	testCases := []struct {
+		path    string
+		charset string
+		err     error
+	}{
+		{path: "testdata/hello-utf8.txt", charset: "UTF-8", err: nil},
+		{path: "testdata/hello-sjis.txt", charset: "Shift_JIS", err: nil},
+		{path: "testdata/hello-euc.txt", charset: "EUC-JP", err: nil},
+		{path: "testdata/not-exist.txt", charset: "", err: os.ErrNotExist},
+	}
+	for _, tc := range testCases {
+		for _, size := range []int64{0, 30} {
+			res, err := DetectFile(tc.path, &Options{Strategy: StrategyJapanese, SampleSize: size})
+			if !errs.Is(err, tc.err) {
+				t.Errorf("DetectFile(%s) error = \"%+v\", want \"%+v\".", tc.path, err, tc.err)
+			}
+			if err == nil && (len(res) == 0 || res[0].Charset != tc.charset) {
+				t.Errorf("DetectFile(%s) = %v, want %v.", tc.path, res, tc.charset)
+			}
+		}
+	}
+}
+
+func TestSampleFile(t *testing.T) {
+	text, err := os.ReadFile("testdata/hello-utf8.txt")
+	if err != nil {
+		t.Fatalf("os.ReadFile() error = \"%+v\", want nil.", err)
+	}
+	for _, size := range []int64{0, 30, int64(len(text))} {
+		b, err := SampleFile("testdata/hello-utf8.txt", size)
+		if err != nil {
+			t.Errorf("SampleFile(%d) error = \"%+v\", want nil.", size, err)
+			continue
+		}
+		if size == 0 || size >= int64(len(text)) {
+			if !bytes.Equal(b, text) {
+				t.Errorf("SampleFile(%d) = %q, want %q.", size, b, text)
+			}
+		} else if int64(len(b)) > size || !utf8.Valid(b) {
+			t.Errorf("SampleFile(%d) = %q, want valid UTF-8 text less than or equal to %d bytes.", size, b, size)
+		}
+	}
+	if _, err := SampleFile("testdata/not-exist.txt", 0); !errs.Is(err, os.ErrNotExist) {
+		t.Errorf("SampleFile() error = \"%+v\", want \"%+v\".", err, os.ErrNotExist)
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	testCases := []struct {
+		data []byte
+		bin  bool
+	}{
+		{data: nil, bin: false},
+		{data: textUTF8, bin: false},
+		{data: textSJIS, bin: false},
+		{data: []byte("\x1b$B%F%9%H\x1b(B\r\n\tabc\f"), bin: false},
+		{data: []byte{0xff, 0xfe, 0x42, 0x30, 0x00, 0x00}, bin: false},
+		{data: []byte("\x89PNG\r\n\x1a\n"), bin: true},
+		{data: []byte("%PDF-1.7\n"), bin: true},
+		{data: []byte("PK\x03\x04"), bin: true},
+		{data: []byte("RIFF\x24\x08\x00\x00WAVEfmt "), bin: true},
+		{data: []byte("wOFF\x00\x01\x00\x00"), bin: true},
+		{data: []byte("OggS\x00\x02"), bin: true},
+		{data: []byte("RIFF is a generic container format.\n"), bin: false},
+		{data: []byte("OggS files\n"), bin: false},
+		{data: []byte("wOFF and wOF2 are web font formats.\n"), bin: false},
+		{data: []byte("abc\x00def"), bin: true},
+		{data: []byte("a\x01b\x02c\x03d\x04e"), bin: true},
+		{data: []byte("abcdefghijklmnopqrstuvwxyz\x01"), bin: false},
+		{data: []byte("H\x00e\x00l\x00l\x00o\x00\n\x00"), bin: false},
+		{data: []byte("\x00H\x00e\x00l\x00l\x00o\x00\n"), bin: false},
+		{data: []byte("\x42\x30\x44\x30a\x00\x3d\xd8\x00\xde"), bin: false},
+		{data: []byte("H\x00\x00\x00i\x00\x00\x00\n\x00\x00\x00"), bin: false},
+		{data: []byte("\x00\x00\x00H\x00\x00\x00i\x00\x00\x30\x42"), bin: false},
+		{data: []byte("H\x00\x00\x00i\x00"), bin: true},
+		{data: []byte("\x01\x00\x02\x00\x03\x00\x04\x00"), bin: true},
+		{data: []byte("H\x00i\x00!"), bin: true},
+	}
+	for _, tc := range testCases {
+		if bin := IsBinary(tc.data); bin != tc.bin {
+			t.Errorf("IsBinary(%q) = %v, want %v.", tc.data, bin, tc.bin)
+		}
+		bin, r, err := IsBinaryReader(bytes.NewReader(tc.data), &Options{SampleSize: 8})
+		if err != nil {
+			t.Errorf("IsBinaryReader(%q) error = \"%+v\", want nil.", tc.data, err)
+			continue
+		}
+		if b, _ := io.ReadAll(r); !bytes.Equal(b, tc.data) {
+			t.Errorf("IsBinaryReader(%q) returns reader of %q, want %q.", tc.data, b, tc.data)
+		}
+		if bin != IsBinary(tc.data[:min(len(tc.data), 8)]) {
+			t.Errorf("IsBinaryReader(%q) = %v, want result of the first 8 bytes.", tc.data, bin)
+		}
+	}
+}
+
+func TestStrategyOf(t *testing.T) {
+	for _, name := range StrategyList() {
+		s, err := StrategyOf(strings.ToUpper(name))
+		if err != nil {
+			t.Errorf("StrategyOf(%s) error = \"%+v\", want nil.", name, err)
+		} else if s.String() != name {
+			t.Errorf("StrategyOf(%s) = %v, want %v.", name, s, name)
+		}
+	}
+	if _, err := StrategyOf("foo"); !errs.Is(err, ecode.ErrInvalidStrategy) {
+		t.Errorf("StrategyOf(foo) error = \"%+v\", want \"%+v\".", err, ecode.ErrInvalidStrategy)
+	}
+}
+
 func TestEncodingNil(t *testing.T) {
 	_, err := Encoding(io.Reader(nil))
 	if !errs.Is(err, ecode.ErrNullPointer) {
 		t.Errorf("Encoding() error = \"%+v\", want \"%+v\".", err, ecode.ErrNullPointer)
 	}
+	_, err = Detect(io.Reader(nil))
+	if !errs.Is(err, ecode.ErrNullPointer) {
+		t.Errorf("Detect() error = \"%+v\", want \"%+v\".", err, ecode.ErrNullPointer)
+	}
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
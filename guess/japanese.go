@@ -0,0 +1,289 @@
+package guess
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+//iso2022jpEscapes is list of escape sequences in ISO-2022-JP family, ordered by priority of encoding name.
+var iso2022jpEscapes = []struct {
+	seq     string
+	charset string
+}{
+	{seq: "\x1b$(Q", charset: "ISO-2022-JP-2004"}, //JIS X 0213:2004 plane 1
+	{seq: "\x1b$(O", charset: "ISO-2022-JP-3"},    //JIS X 0213:2000 plane 1
+	{seq: "\x1b$(P", charset: "ISO-2022-JP-3"},    //JIS X 0213 plane 2
+	{seq: "\x1b$(D", charset: "ISO-2022-JP-1"},    //JIS X 0212
+	{seq: "\x1b(I", charset: "CP50221"},           //JIS X 0201 katakana
+	{seq: "\x1b$B", charset: "ISO-2022-JP"},       //JIS X 0208-1983
+	{seq: "\x1b$@", charset: "ISO-2022-JP"},       //JIS X 0208-1978
+	{seq: "\x1b(J", charset: "ISO-2022-JP"},       //JIS X 0201 Roman
+}
+
+//penaltyInvalid is weight of invalid byte sequence in scoring.
+const penaltyInvalid = 4
+
+//weightKana is likelihood of JIS X 0201 katakana (half-width kana), which is common in old mail and CSV files.
+const weightKana = 0.8
+
+//ambiguousMargin is maximum difference of likelihood between EUC-JP and Shift_JIS to be ambiguous.
+const ambiguousMargin = 0.3
+
+//kanaWeight returns likelihood of JIS X 0201 katakana.
+//Punctuations (0xA1-0xA5) are rare, but frequent in EUC-JP text read as Shift_JIS (0xA4 and 0xA5 are leading bytes of kana in EUC-JP).
+func kanaWeight(c byte) float64 {
+	if c <= 0xa5 {
+		return 0.2
+	}
+	return weightKana
+}
+
+//jaScore is score of a character encoding in Japanese text.
+type jaScore struct {
+	charset string
+	weight  float64 //sum of likelihood of each multibyte character
+	chars   int     //count of multibyte characters
+	invalid int     //count of invalid byte sequences
+}
+
+func (s jaScore) confidence() int {
+	return int(s.likelihood()*100 + 0.5)
+}
+
+func (s jaScore) likelihood() float64 {
+	total := s.chars + s.invalid*penaltyInvalid
+	if total == 0 {
+		return 0
+	}
+	return s.weight / float64(total)
+}
+
+//ambiguous returns true if the text is valid in both encodings with similar likelihood.
+func ambiguous(s1, s2 jaScore) bool {
+	return s1.chars > 0 && s1.invalid == 0 && s2.chars > 0 && s2.invalid == 0 && math.Abs(s1.likelihood()-s2.likelihood()) <= ambiguousMargin
+}
+
+//detectJapanese guesses character encoding of Japanese text by heuristics.
+//Escape sequences are checked for ISO-2022-JP in 7bit text.
+//Otherwise UTF-8, EUC-JP and Shift_JIS (CP932) are scored by validity and likelihood of byte sequences.
+func detectJapanese(b []byte) []Result {
+	if !has8bit(b) {
+		for _, esc := range iso2022jpEscapes {
+			if bytes.Contains(b, []byte(esc.seq)) {
+				return []Result{{Charset: esc.charset, Confidence: 100, Language: "ja"}}
+			}
+		}
+		return []Result{{Charset: "UTF-8", Confidence: 100}} //ASCII text
+	}
+	euc, sjis := scoreEUCJP(b), scoreSJIS(b)
+	scores := []jaScore{scoreUTF8(b), euc, sjis}
+	res := []Result{}
+	for i, s := range scores {
+		c := s.confidence()
+		if i > 0 && ambiguous(euc, sjis) {
+			//both EUC-JP and Shift_JIS are valid (e.g. text of kana only): share the confidence
+			c = int(s.likelihood()*100/(euc.likelihood()+sjis.likelihood()) + 0.5)
+		}
+		if c > 0 {
+			r := Result{Charset: s.charset, Confidence: c, Language: "ja"}
+			if s.charset == "UTF-8" && !hasJapanese(b) {
+				r.Language = ""
+			}
+			res = append(res, r)
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Confidence > res[j].Confidence
+	})
+	return res
+}
+
+func has8bit(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+func hasJapanese(b []byte) bool {
+	for _, r := range string(b) {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han) {
+			return true
+		}
+	}
+	return false
+}
+
+//scoreUTF8 scores text as UTF-8.
+//All valid multibyte characters have full likelihood, because UTF-8 is rarely valid by chance.
+func scoreUTF8(b []byte) jaScore {
+	s := jaScore{charset: "UTF-8"}
+	for i := 0; i < len(b); {
+		if b[i] < utf8.RuneSelf {
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(b[i:])
+		switch {
+		case r != utf8.RuneError || size > 1:
+			s.weight++
+			s.chars++
+		case !utf8.FullRune(b[i:]):
+			return s //incomplete character at the end of text
+		default:
+			s.invalid++
+		}
+		i += size
+	}
+	return s
+}
+
+//scoreEUCJP scores text as EUC-JP.
+func scoreEUCJP(b []byte) jaScore {
+	s := jaScore{charset: "EUC-JP"}
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c < utf8.RuneSelf:
+			i++
+			continue
+		case c == 0x8e: //JIS X 0201 katakana
+			if i+1 >= len(b) {
+				s.invalid++ //incomplete character at the end of text
+				return s
+			}
+			if 0xa1 <= b[i+1] && b[i+1] <= 0xdf {
+				s.weight += kanaWeight(b[i+1])
+				s.chars++
+				i += 2
+				continue
+			}
+		case c == 0x8f: //JIS X 0212
+			if i+2 >= len(b) {
+				s.invalid++ //incomplete character at the end of text
+				return s
+			}
+			if isEUCByte(b[i+1]) && isEUCByte(b[i+2]) {
+				s.weight += 0.3
+				s.chars++
+				i += 3
+				continue
+			}
+		case isEUCByte(c):
+			if i+1 >= len(b) {
+				s.invalid++ //incomplete character at the end of text (e.g. odd run of half-width kana in Shift_JIS)
+				return s
+			}
+			if isEUCByte(b[i+1]) {
+				s.weight += jisRowWeight(int(c) - 0xa0)
+				s.chars++
+				i += 2
+				continue
+			}
+		}
+		s.invalid++
+		i++
+	}
+	return s
+}
+
+//scoreSJIS scores text as Shift_JIS.
+//It is reported as Windows-31J if the text contains extensions of CP932.
+func scoreSJIS(b []byte) jaScore {
+	s := jaScore{charset: "Shift_JIS"}
+	cp932 := false
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case c < utf8.RuneSelf:
+			i++
+			continue
+		case 0xa1 <= c && c <= 0xdf: //JIS X 0201 katakana
+			s.weight += kanaWeight(c)
+			s.chars++
+			i++
+			continue
+		case (0x81 <= c && c <= 0x9f) || (0xe0 <= c && c <= 0xfc):
+			if i+1 >= len(b) {
+				s.invalid++ //incomplete character at the end of text
+				i = len(b)
+				continue
+			}
+			if t := b[i+1]; 0x40 <= t && t <= 0xfc && t != 0x7f {
+				row := sjisRow(c, t)
+				switch {
+				case c == 0x8e && 0xa1 <= t && t <= 0xdf: //also JIS X 0201 katakana in EUC-JP (SS2)
+					s.weight += kanaWeight(t) - 0.2
+				case c >= 0xf0 && c <= 0xf9: //user-defined area
+					s.weight += 0.1
+				case c >= 0xfa || row == 13 || (89 <= row && row <= 92): //extensions of CP932
+					s.weight += 0.3
+					cp932 = true
+				default:
+					s.weight += jisRowWeight(row)
+				}
+				s.chars++
+				i += 2
+				continue
+			}
+		}
+		s.invalid++
+		i++
+	}
+	if cp932 {
+		s.charset = "Windows-31J"
+	}
+	return s
+}
+
+func isEUCByte(c byte) bool {
+	return 0xa1 <= c && c <= 0xfe
+}
+
+//sjisRow returns row number of JIS X 0208 from double-byte character of Shift_JIS (lead byte is 0x81-0x9F or 0xE0-0xEF).
+func sjisRow(lead, trail byte) int {
+	row := int(lead-0x81)*2 + 1
+	if lead >= 0xe0 {
+		row = int(lead-0xe0)*2 + 63
+	}
+	if trail >= 0x9f {
+		row++
+	}
+	return row
+}
+
+//jisRowWeight returns likelihood of character in JIS X 0208 row in Japanese text.
+func jisRowWeight(row int) float64 {
+	switch {
+	case row == 1 || row == 4 || row == 5 || (16 <= row && row <= 47): //punctuations, hiragana, katakana and kanji level 1
+		return 1.0
+	case row == 3: //digits and latin letters
+		return 0.8
+	case 48 <= row && row <= 84: //kanji level 2
+		return 0.6
+	case row == 2 || (6 <= row && row <= 8): //symbols, greek, cyrillic and box drawings
+		return 0.4
+	default: //unassigned rows in JIS X 0208
+		return 0.1
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
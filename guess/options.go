@@ -0,0 +1,36 @@
+package guess
+
+//Options is options of guessing character encoding
+type Options struct {
+	Strategy   Strategy //strategy for guessing character encoding (default: StrategyChardet)
+	SampleSize int64    //maximum size of sample in bytes (whole text if 0)
+}
+
+func (opts *Options) strategy() Strategy {
+	if opts == nil {
+		return StrategyChardet
+	}
+	return opts.Strategy
+}
+
+func (opts *Options) sampleSize() int64 {
+	if opts == nil || opts.SampleSize < 0 {
+		return 0
+	}
+	return opts.SampleSize
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,38 @@
+package guess
+
+import "fmt"
+
+//Result is a guess of character encoding.
+type Result struct {
+	Charset    string `json:"charset"`
+	Confidence int    `json:"confidence"` //0 to 100
+	Language   string `json:"language,omitempty"`
+	BOM        bool   `json:"bom"` //true if the text starts with BOM
+}
+
+//String method is Stringer for Result.
+func (r Result) String() string {
+	s := fmt.Sprintf("%s (confidence: %d", r.Charset, r.Confidence)
+	if len(r.Language) > 0 {
+		s += ", language: " + r.Language
+	}
+	if r.BOM {
+		s += ", with BOM"
+	}
+	return s + ")"
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
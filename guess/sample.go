@@ -0,0 +1,173 @@
+package guess
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+//DefaultSampleSize is default size of sample for guessing character encoding in bytes.
+const DefaultSampleSize = 64 * 1024
+
+//readSample reads sample at the beginning of byte stream (whole text if n <= 0).
+func readSample(txt io.Reader, n int64) ([]byte, error) {
+	if n > 0 {
+		txt = io.LimitReader(txt, n)
+	}
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(txt); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+//sampleAt reads head, middle and tail of text as sample (whole text if n <= 0 or size <= n).
+//Middle and tail parts are cut at newlines if possible, otherwise at UTF-8 character boundaries, not to break multibyte characters.
+//In UTF-16 and UTF-32 text (found by BOM or pattern of NUL bytes at the head), they are cut at boundaries of code units.
+func sampleAt(r io.ReaderAt, size, n int64) ([]byte, error) {
+	if n <= 0 || size <= n {
+		return readSample(io.NewSectionReader(r, 0, size), 0)
+	}
+	part := n / 3
+	offsets := []int64{0, (size - part) / 2, size - part}
+	sample := make([]byte, 0, n)
+	unit, be := 1, false
+	for i, off := range offsets {
+		if i > 0 && unit > 1 {
+			if i < len(offsets)-1 {
+				off -= off % int64(unit)
+			} else {
+				off += (int64(unit) - off%int64(unit)) % int64(unit) //tail part is read to the end of text
+			}
+		}
+		b := make([]byte, part)
+		m, err := r.ReadAt(b, off)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		b = b[:m]
+		if i == 0 {
+			unit, be = codeUnitOf(b)
+		}
+		if unit > 1 {
+			sample = append(sample, cutWide(b, unit, be, i > 0, i < len(offsets)-1)...)
+			continue
+		}
+		if i > 0 {
+			if j := bytes.IndexByte(b, '\n'); j >= 0 {
+				b = b[j+1:]
+			} else {
+				b = b[leadingContinuation(b):]
+			}
+		}
+		if i < len(offsets)-1 {
+			if j := bytes.LastIndexByte(b, '\n'); j >= 0 {
+				b = b[:j+1]
+			} else {
+				b = b[:len(b)-trailingIncomplete(b)]
+			}
+		}
+		sample = append(sample, b...)
+	}
+	return sample, nil
+}
+
+//codeUnitOf returns size of code unit (1, 2 or 4) and byte order (true if big endian) of the text, by BOM or pattern of NUL bytes.
+func codeUnitOf(b []byte) (int, bool) {
+	if res, ok := detectBOM(b); ok {
+		switch res.Charset {
+		case "UTF-16BE":
+			return 2, true
+		case "UTF-16LE":
+			return 2, false
+		case "UTF-32BE":
+			return 4, true
+		case "UTF-32LE":
+			return 4, false
+		}
+		return 1, false
+	}
+	if unit, be := wideText(b[:len(b)/4*4]); unit > 0 {
+		return unit, be
+	}
+	return 1, false
+}
+
+//cutWide cuts part of UTF-16 or UTF-32 text at newlines if possible, otherwise at boundaries of code units (and surrogate pairs).
+//The beginning of the part is cut if front is true, and the end is cut if back is true.
+func cutWide(b []byte, unit int, be, front, back bool) []byte {
+	b = b[:len(b)/unit*unit]
+	if front {
+		j := 0
+		for k := 0; k < len(b); k += unit {
+			if codeUnit(b[k:k+unit], be) == '\n' {
+				j = k + unit
+				break
+			}
+		}
+		if j == 0 && len(b) >= unit && isLowSurrogate(codeUnit(b[:unit], be)) {
+			j = unit
+		}
+		b = b[j:]
+	}
+	if back {
+		j := len(b)
+		for k := len(b) - unit; k >= 0; k -= unit {
+			if codeUnit(b[k:k+unit], be) == '\n' {
+				j = k + unit
+				break
+			}
+		}
+		if j == len(b) && len(b) >= unit && isHighSurrogate(codeUnit(b[len(b)-unit:], be)) {
+			j -= unit
+		}
+		b = b[:j]
+	}
+	return b
+}
+
+func isHighSurrogate(u rune) bool {
+	return 0xd800 <= u && u < 0xdc00
+}
+
+func isLowSurrogate(u rune) bool {
+	return 0xdc00 <= u && u < 0xe000
+}
+
+//leadingContinuation returns length of UTF-8 continuation bytes (0x80-0xBF) at the beginning of b.
+func leadingContinuation(b []byte) int {
+	i := 0
+	for i < len(b) && i < utf8.UTFMax-1 && !utf8.RuneStart(b[i]) {
+		i++
+	}
+	return i
+}
+
+//trailingIncomplete returns length of incomplete UTF-8 character at the end of b.
+func trailingIncomplete(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if utf8.FullRune(b[i:]) {
+				return 0
+			}
+			return len(b) - i
+		}
+	}
+	return 0
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,65 @@
+package guess
+
+import (
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+//Strategy is type of strategy for guessing character encoding
+type Strategy int
+
+const (
+	StrategyChardet  Strategy = iota //github.com/saintfish/chardet package (default)
+	StrategyJapanese                 //heuristics for Japanese text (ISO-2022-JP, Shift_JIS, EUC-JP, UTF-8)
+)
+
+var strategyNamesMap = map[string]Strategy{
+	"chardet":  StrategyChardet,
+	"japanese": StrategyJapanese,
+}
+
+func (s Strategy) String() string {
+	return strategyName(s)
+}
+
+func strategyName(s Strategy) string {
+	for key, value := range strategyNamesMap {
+		if value == s {
+			return key
+		}
+	}
+	return ""
+}
+
+//StrategyList returns list of strategy for guessing character encoding
+func StrategyList() []string {
+	return []string{
+		strategyName(StrategyChardet),
+		strategyName(StrategyJapanese),
+	}
+}
+
+//StrategyOf returns strategy for guessing character encoding from name string
+func StrategyOf(name string) (Strategy, error) {
+	if s, ok := strategyNamesMap[strings.ToLower(name)]; ok {
+		return s, nil
+	}
+	return StrategyChardet, errs.Wrap(ecode.ErrInvalidStrategy, errs.WithContext("name", name))
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,21 +1,16 @@
 package kana
 
 import (
-	"bytes"
 	"io"
-	"strings"
 
 	"github.com/goark/errs"
 	"github.com/goark/kkconv"
+	"golang.org/x/text/transform"
 )
 
 //Convert function converts kana character in text stream.
 func Convert(f Form, writer io.Writer, txt io.Reader, foldFlag bool) error {
-	buf := &bytes.Buffer{}
-	if _, err := buf.ReadFrom(txt); err != nil {
-		return errs.Wrap(err)
-	}
-	if _, err := strings.NewReader(ConvertString(f, buf.String(), foldFlag)).WriteTo(writer); err != nil {
+	if _, err := io.Copy(writer, transform.NewReader(txt, NewTransformer(f, foldFlag))); err != nil {
 		return errs.Wrap(err)
 	}
 	return nil
@@ -35,7 +30,7 @@ func ConvertString(f Form, txt string, foldFlag bool) string {
 	}
 }
 
-/* Copyright 2020-2021 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goark/gnkf/dump"
 	"github.com/goark/gnkf/ecode"
@@ -167,7 +168,30 @@ func TestTranslateFold(t *testing.T) {
 	}
 }
 
-/* Copyright 2020-2021 Spiegel
+func TestConvertStream(t *testing.T) {
+	testCases := []string{
+		"ﾍﾟﾝｷﾞﾝ　ペンギン　ぺんぎん ABCａｂｃ",
+		"ヷヸヹヺ わ\u3099ゐ\u3099ゑ\u3099を\u3099 ﾜﾞｦﾞ",
+		"ㇰㇱㇲ𛅤𛅥ヮヵヶ ゃゅょっ ｬｭｮｯ",
+		"ﾞﾟｶﾞﾞ\u3099\xff\xe3\x82",
+	}
+	for _, formName := range FormList() {
+		f, _ := FormOf(formName)
+		for _, foldFlag := range []bool{false, true} {
+			for _, tc := range testCases {
+				str := ConvertString(f, tc, foldFlag)
+				buf := &bytes.Buffer{}
+				if err := Convert(f, buf, iotest.OneByteReader(strings.NewReader(tc)), foldFlag); err != nil {
+					t.Errorf("Convert(%v, %v) error = \"%+v\", want nil.", f, foldFlag, err)
+				} else if buf.String() != str {
+					t.Errorf("Convert(%v, %v, %q) = %q, want %q.", f, foldFlag, tc, buf.String(), str)
+				}
+			}
+		}
+	}
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -0,0 +1,86 @@
+package kana
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+//maxCacheSize is maximum number of cached conversions in transformer
+const maxCacheSize = 1 << 14
+
+//kanaTransformer is transform.Transformer for converting kana characters in text stream.
+//Kana and (semi-)voiced sound mark split across chunks are converted as one character.
+type kanaTransformer struct {
+	form     Form
+	foldFlag bool
+	cache    map[string]string
+}
+
+//NewTransformer returns transform.Transformer instance for converting kana characters in text stream.
+func NewTransformer(f Form, foldFlag bool) transform.Transformer {
+	return &kanaTransformer{form: f, foldFlag: foldFlag, cache: map[string]string{}}
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *kanaTransformer) Reset() {}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *kanaTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		_, size := utf8.DecodeRune(src[nSrc:])
+		if size <= 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		//character and following sound mark
+		end := nSrc + size
+		if !atEOF && !utf8.FullRune(src[end:]) {
+			return nDst, nSrc, transform.ErrShortSrc //wait for next character
+		}
+		if m, msize := utf8.DecodeRune(src[end:]); isSoundMark(m) {
+			end += msize
+		}
+		s := t.convert(src[nSrc:end])
+		if nDst+len(s) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], s)
+		nSrc = end
+	}
+	return nDst, nSrc, nil
+}
+
+func (t *kanaTransformer) convert(b []byte) string {
+	if s, ok := t.cache[string(b)]; ok {
+		return s
+	}
+	s := ConvertString(t.form, string(b), t.foldFlag)
+	if len(t.cache) < maxCacheSize {
+		t.cache[string(b)] = s
+	}
+	return s
+}
+
+//isSoundMark returns true if r is (semi-)voiced sound mark for combining.
+func isSoundMark(r rune) bool {
+	switch r {
+	case 0x3099, 0x309a, 0xff9e, 0xff9f:
+		return true
+	}
+	return false
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
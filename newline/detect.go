@@ -0,0 +1,131 @@
+package newline
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goark/errs"
+)
+
+//Result is result of newline detection in text stream (UTF-8 encoding).
+type Result struct {
+	LF   int //count of LF (U+000A)
+	CR   int //count of CR (U+000D)
+	CRLF int //count of CR+LF
+	NEL  int //count of NEL (U+0085)
+	LS   int //count of LINE SEPARATOR (U+2028)
+	PS   int //count of PARAGRAPH SEPARATOR (U+2029)
+	VT   int //count of VT (U+000B), not included in Total and Mixed
+	FF   int //count of FF (U+000C), not included in Total and Mixed
+}
+
+//Detect function counts newlines in the text stream.
+func Detect(txt io.Reader) (*Result, error) {
+	res := &Result{}
+	r := bufio.NewReader(txt)
+	for {
+		c, _, err := r.ReadRune()
+		if err != nil {
+			if errs.Is(err, io.EOF) {
+				break
+			}
+			return res, errs.Wrap(err)
+		}
+		switch c {
+		case '\n':
+			res.LF++
+		case '\r':
+			if b, err := r.Peek(1); err == nil && b[0] == '\n' {
+				_, _ = r.Discard(1)
+				res.CRLF++
+			} else {
+				res.CR++
+			}
+		case 0x85:
+			res.NEL++
+		case 0x2028:
+			res.LS++
+		case 0x2029:
+			res.PS++
+		case '\v':
+			res.VT++
+		case '\f':
+			res.FF++
+		}
+	}
+	return res, nil
+}
+
+//counts returns counts of each kind of newline.
+//VT and FF are excluded, because they are used as page breaks in text with other newlines (e.g. ^L in source code).
+func (r *Result) counts() []int {
+	return []int{r.LF, r.CR, r.CRLF, r.NEL, r.LS, r.PS}
+}
+
+//Total returns total count of newlines (except VT and FF).
+func (r *Result) Total() int {
+	total := 0
+	for _, c := range r.counts() {
+		total += c
+	}
+	return total
+}
+
+//Mixed returns true if the text contains different kinds of newlines.
+func (r *Result) Mixed() bool {
+	kinds := 0
+	for _, c := range r.counts() {
+		if c > 0 {
+			kinds++
+		}
+	}
+	return kinds > 1
+}
+
+//Dominant returns the most frequent newline form (LF, CR, or CRLF).
+//It returns false if the text contains none of them.
+func (r *Result) Dominant() (Form, bool) {
+	f, max := LF, 0
+	for _, v := range []struct {
+		f Form
+		c int
+	}{{LF, r.LF}, {CRLF, r.CRLF}, {CR, r.CR}} {
+		if v.c > max {
+			f, max = v.f, v.c
+		}
+	}
+	return f, max > 0
+}
+
+//String method is Stringer of Result.
+func (r *Result) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "lf: %d, cr: %d, crlf: %d, nel: %d, ls: %d, ps: %d, vt: %d, ff: %d", r.LF, r.CR, r.CRLF, r.NEL, r.LS, r.PS, r.VT, r.FF)
+	if f, ok := r.Dominant(); ok {
+		fmt.Fprintf(&sb, " (dominant: %v", formName(f))
+		if r.Mixed() {
+			sb.WriteString(", mixed")
+		}
+		sb.WriteString(")")
+	} else if r.Mixed() {
+		sb.WriteString(" (mixed)")
+	}
+	return sb.String()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
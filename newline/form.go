@@ -27,6 +27,14 @@ var (
 		CR:   "\r",
 		CRLF: "\r\n",
 	}
+	//unicodeNewlines is list of Unicode line terminators other than LF and CR
+	unicodeNewlines = []string{
+		"\u0085", //NEL (NEXT LINE)
+		"\u2028", //LS (LINE SEPARATOR)
+		"\u2029", //PS (PARAGRAPH SEPARATOR)
+		"\v",     //VT (LINE TABULATION)
+		"\f",     //FF (FORM FEED)
+	}
 )
 
 func formName(f Form) string {
@@ -55,6 +63,11 @@ func FormOf(name string) (Form, error) {
 	return Form(0), errs.Wrap(ecode.ErrInvalidNewlineForm, errs.WithContext("name", name))
 }
 
+//String method is Stringer of Form.
+func (f Form) String() string {
+	return formName(f)
+}
+
 //Code returns newline code string
 func (f Form) Code() string {
 	if c, ok := newlineCodeMap[f]; ok {
@@ -72,7 +85,20 @@ func NewReplacer(frm Form) *strings.Replacer {
 	)
 }
 
-/* Copyright 2020 Spiegel
+//NewUnicodeReplacer returns strings.Replacer instance for translating newline including Unicode line terminators (NEL, LS, PS, VT, FF)
+func NewUnicodeReplacer(frm Form) *strings.Replacer {
+	oldnew := []string{
+		CRLF.Code(), frm.Code(),
+		LF.Code(), frm.Code(),
+		CR.Code(), frm.Code(),
+	}
+	for _, nl := range unicodeNewlines {
+		oldnew = append(oldnew, nl, frm.Code())
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
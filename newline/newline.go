@@ -1,30 +1,30 @@
 package newline
 
 import (
-	"bytes"
 	"io"
 
 	"github.com/goark/errs"
+	"golang.org/x/text/transform"
 )
 
 //Convert function convert newline in the text stream.
 func Convert(formName string, writer io.Writer, txt io.Reader) error {
+	return ConvertWithOptions(formName, writer, txt, nil)
+}
+
+//ConvertWithOptions function convert newline in the text stream with options.
+func ConvertWithOptions(formName string, writer io.Writer, txt io.Reader, opts *Options) error {
 	f, err := FormOf(formName)
 	if err != nil {
 		return errs.Wrap(err, errs.WithContext("formName", formName))
 	}
-
-	buf := &bytes.Buffer{}
-	if _, err := buf.ReadFrom(txt); err != nil {
-		return errs.Wrap(err)
-	}
-	if _, err := NewReplacer(f).WriteString(writer, buf.String()); err != nil {
+	if _, err := io.Copy(writer, transform.NewReader(txt, NewTransformer(f, opts))); err != nil {
 		return errs.Wrap(err)
 	}
 	return nil
 }
 
-/* Copyright 2020 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
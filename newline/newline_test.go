@@ -2,8 +2,10 @@ package newline
 
 import (
 	"bytes"
+	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/dump"
@@ -16,6 +18,11 @@ func TestFormList(t *testing.T) {
 	if str != res {
 		t.Errorf("FormList() = \"%+v\", want \"%+v\".", str, res)
 	}
+	for _, name := range FormList() {
+		if f, _ := FormOf(name); f.String() != name {
+			t.Errorf("Form.String() = \"%+v\", want \"%+v\".", f.String(), name)
+		}
+	}
 }
 
 func TestTranslate(t *testing.T) {
@@ -61,7 +68,94 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
-/* Copyright 2020 Spiegel
+func TestConvertStream(t *testing.T) {
+	inp := "abc\r\ndef\r\rghi\n\rjkl\u0085mno\u2028pqr\u2029stu\v\fvwx\u00a9\u2027\r"
+	for _, formName := range FormList() {
+		f, _ := FormOf(formName)
+		for _, opts := range []*Options{nil, {Unicode: true}} {
+			str := NewReplacer(f).Replace(inp)
+			if opts.unicode() {
+				str = NewUnicodeReplacer(f).Replace(inp)
+			}
+			buf := &bytes.Buffer{}
+			if err := ConvertWithOptions(formName, buf, iotest.OneByteReader(strings.NewReader(inp)), opts); err != nil {
+				t.Errorf("ConvertWithOptions(%s, %+v) error = \"%+v\", want nil.", formName, opts, err)
+			} else if buf.String() != str {
+				t.Errorf("ConvertWithOptions(%s, %+v, %q) = %q, want %q.", formName, opts, inp, buf.String(), str)
+			}
+		}
+	}
+}
+
+func TestConvertWithOptions(t *testing.T) {
+	testCases := []struct {
+		inp  string
+		opts *Options
+		outp string
+	}{
+		{inp: "", opts: &Options{FinalNewline: true}, outp: ""},
+		{inp: "abc", opts: &Options{FinalNewline: true}, outp: "abc\n"},
+		{inp: "abc\r\n\r\n\n", opts: &Options{FinalNewline: true}, outp: "abc\n"},
+		{inp: "\n\n", opts: &Options{FinalNewline: true}, outp: ""},
+		{inp: "abc \t\u3000\r\ndef  ghi  \n  \n", opts: &Options{TrimSpace: true}, outp: "abc\ndef  ghi\n\n"},
+		{inp: "abc  ", opts: &Options{TrimSpace: true}, outp: "abc"},
+		{inp: "\n\nabc\n\n\n\ndef\nghi\n\n\n", opts: &Options{CollapseBlankLines: true}, outp: "\nabc\n\ndef\nghi\n\n"},
+		{inp: "abc\n \n\t\n\ndef \n\n", opts: &Options{TrimSpace: true, CollapseBlankLines: true, FinalNewline: true}, outp: "abc\n\ndef\n"},
+		{inp: "abc\u2028\u2028\u2028def\u0085", opts: &Options{Unicode: true, CollapseBlankLines: true, FinalNewline: true}, outp: "abc\n\ndef\n"},
+		{inp: "\u00e3\u3042 \u3000\u3044", opts: &Options{TrimSpace: true, FinalNewline: true}, outp: "\u00e3\u3042 \u3000\u3044\n"},
+	}
+	for _, tc := range testCases {
+		for _, r := range []io.Reader{strings.NewReader(tc.inp), iotest.OneByteReader(strings.NewReader(tc.inp))} {
+			buf := &bytes.Buffer{}
+			if err := ConvertWithOptions("lf", buf, r, tc.opts); err != nil {
+				t.Errorf("ConvertWithOptions(%+v) error = \"%+v\", want nil.", tc.opts, err)
+			} else if buf.String() != tc.outp {
+				t.Errorf("ConvertWithOptions(%+v, %q) = %q, want %q.", tc.opts, tc.inp, buf.String(), tc.outp)
+			}
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	testCases := []struct {
+		inp      string
+		res      Result
+		dominant Form
+		ok       bool
+		mixed    bool
+		total    int
+	}{
+		{inp: "abc", res: Result{}, dominant: LF, ok: false, mixed: false, total: 0},
+		{inp: "a\nb\nc\n", res: Result{LF: 3}, dominant: LF, ok: true, mixed: false, total: 3},
+		{inp: "a\r\nb\r\nc\n", res: Result{LF: 1, CRLF: 2}, dominant: CRLF, ok: true, mixed: true, total: 3},
+		{inp: "a\rb\r\r\n", res: Result{CR: 2, CRLF: 1}, dominant: CR, ok: true, mixed: true, total: 3},
+		{inp: "a\u0085b\u2028c\u2029", res: Result{NEL: 1, LS: 1, PS: 1}, dominant: LF, ok: false, mixed: true, total: 3},
+		{inp: "a\nb\fc\vd\n", res: Result{LF: 2, VT: 1, FF: 1}, dominant: LF, ok: true, mixed: false, total: 2},
+		{inp: "a\r\n\fb\r\n", res: Result{CRLF: 2, FF: 1}, dominant: CRLF, ok: true, mixed: false, total: 2},
+		{inp: "\f\v", res: Result{VT: 1, FF: 1}, dominant: LF, ok: false, mixed: false, total: 0},
+	}
+	for _, tc := range testCases {
+		res, err := Detect(iotest.OneByteReader(strings.NewReader(tc.inp)))
+		if err != nil {
+			t.Errorf("Detect(%q) error = \"%+v\", want nil.", tc.inp, err)
+			continue
+		}
+		if *res != tc.res {
+			t.Errorf("Detect(%q) = %+v, want %+v.", tc.inp, *res, tc.res)
+		}
+		if f, ok := res.Dominant(); f != tc.dominant || ok != tc.ok {
+			t.Errorf("Dominant(%q) = %v, %v, want %v, %v.", tc.inp, f, ok, tc.dominant, tc.ok)
+		}
+		if res.Mixed() != tc.mixed {
+			t.Errorf("Mixed(%q) = %v, want %v.", tc.inp, res.Mixed(), tc.mixed)
+		}
+		if res.Total() != tc.total {
+			t.Errorf("Total(%q) = %v, want %v.", tc.inp, res.Total(), tc.total)
+		}
+	}
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -0,0 +1,52 @@
+package newline
+
+//Options is options of newline conversion
+type Options struct {
+	Unicode            bool //treat Unicode line terminators (NEL, LS, PS, VT, FF) as newlines
+	FinalNewline       bool //ensure exactly one newline at the end of the text
+	TrimSpace          bool //strip trailing whitespaces in each line
+	CollapseBlankLines bool //collapse runs of blank lines into one blank line
+}
+
+func (opts *Options) unicode() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.Unicode
+}
+
+func (opts *Options) finalNewline() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.FinalNewline
+}
+
+func (opts *Options) trimSpace() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.TrimSpace
+}
+
+func (opts *Options) collapseBlankLines() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.CollapseBlankLines
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
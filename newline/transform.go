@@ -0,0 +1,236 @@
+package newline
+
+import (
+	"bytes"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+)
+
+//newlineTransformer is transform.Transformer for converting newline in text stream.
+//CR at the end of a chunk followed by LF at the start of next chunk is treated as one CRLF.
+//Newlines and trailing whitespaces are held until following text is found,
+//so that trailing whitespaces and blank lines can be removed in the same pass.
+type newlineTransformer struct {
+	code         []byte
+	unicodeFlag  bool
+	finalFlag    bool
+	trimFlag     bool
+	collapseFlag bool
+	spaces       []byte //trailing whitespaces held in current line
+	pending      int    //count of newlines held after last text
+	written      bool   //true if any text is written
+	ended        bool   //true if end of text is processed
+}
+
+//NewTransformer returns transform.Transformer instance for converting newline in text stream.
+func NewTransformer(f Form, opts *Options) transform.Transformer {
+	return &newlineTransformer{
+		code:         []byte(f.Code()),
+		unicodeFlag:  opts.unicode(),
+		finalFlag:    opts.finalNewline(),
+		trimFlag:     opts.trimSpace(),
+		collapseFlag: opts.collapseBlankLines(),
+	}
+}
+
+//Reset method is implementation of transform.Transformer interface.
+func (t *newlineTransformer) Reset() {
+	t.spaces = t.spaces[:0]
+	t.pending = 0
+	t.written = false
+	t.ended = false
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *newlineTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		i := t.index(src[nSrc:])
+		if i == 0 {
+			size, short := t.newlineSize(src[nSrc:])
+			if short && !atEOF {
+				return nDst, nSrc, transform.ErrShortSrc //wait for next bytes
+			}
+			if size > 0 {
+				nSrc += size
+				t.addNewline()
+				if !t.finalFlag && !t.collapseFlag {
+					n, err := t.flush(dst[nDst:])
+					nDst += n
+					if err != nil {
+						return nDst, nSrc, err
+					}
+				}
+				continue
+			}
+			i = 1 + t.index(src[nSrc+1:]) //not newline
+		}
+		n, m, err := t.writeText(dst[nDst:], src[nSrc:nSrc+i], atEOF || nSrc+i < len(src))
+		nDst += n
+		nSrc += m
+		if err != nil {
+			return nDst, nSrc, err
+		}
+	}
+	if atEOF {
+		if !t.ended {
+			t.ended = true
+			t.spaces = t.spaces[:0]
+			if t.finalFlag {
+				t.pending = 0
+				if t.written {
+					t.pending = 1
+				}
+			}
+		}
+		n, err := t.flush(dst[nDst:])
+		return nDst + n, nSrc, err
+	}
+	return nDst, nSrc, nil
+}
+
+//addNewline holds a newline, and drops trailing whitespaces in the line.
+func (t *newlineTransformer) addNewline() {
+	t.spaces = t.spaces[:0]
+	t.pending++
+	if t.collapseFlag {
+		limit := 1 //blank lines at the beginning of text
+		if t.written {
+			limit = 2
+		}
+		if t.pending > limit {
+			t.pending = limit
+		}
+	}
+}
+
+//writeText writes text without newlines, and holds trailing whitespaces if trimFlag is true.
+//atEOF is false if src may end in the middle of a character.
+func (t *newlineTransformer) writeText(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		end := len(src)
+		if t.trimFlag {
+			r, size := utf8.DecodeRune(src[nSrc:])
+			if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc //wait for next bytes
+			}
+			if unicode.IsSpace(r) {
+				t.spaces = append(t.spaces, src[nSrc:nSrc+size]...)
+				nSrc += size
+				continue
+			}
+			end = nSrc + size + indexSpace(src[nSrc+size:])
+		}
+		n, err := t.flush(dst[nDst:])
+		nDst += n
+		if err != nil {
+			return nDst, nSrc, err
+		}
+		n = copy(dst[nDst:], src[nSrc:end])
+		nDst += n
+		nSrc += n
+		if n > 0 {
+			t.written = true
+		}
+		if nSrc < end {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+	}
+	return nDst, nSrc, nil
+}
+
+//flush writes held newlines and whitespaces.
+func (t *newlineTransformer) flush(dst []byte) (int, error) {
+	n := 0
+	for ; t.pending > 0; t.pending-- {
+		if n+len(t.code) > len(dst) {
+			return n, transform.ErrShortDst
+		}
+		n += copy(dst[n:], t.code)
+	}
+	m := copy(dst[n:], t.spaces)
+	t.spaces = t.spaces[:copy(t.spaces, t.spaces[m:])]
+	if len(t.spaces) > 0 {
+		return n + m, transform.ErrShortDst
+	}
+	return n + m, nil
+}
+
+//indexSpace returns index of first whitespace or incomplete character in b.
+func indexSpace(b []byte) int {
+	for i := 0; i < len(b); {
+		r, size := rune(b[i]), 1
+		if r >= utf8.RuneSelf {
+			r, size = utf8.DecodeRune(b[i:])
+			if r == utf8.RuneError && size == 1 && !utf8.FullRune(b[i:]) {
+				return i
+			}
+		}
+		if unicode.IsSpace(r) {
+			return i
+		}
+		i += size
+	}
+	return len(b)
+}
+
+//index returns index of first byte of newline candidate.
+func (t *newlineTransformer) index(b []byte) int {
+	if !t.unicodeFlag {
+		if i := bytes.IndexAny(b, "\r\n"); i >= 0 {
+			return i
+		}
+		return len(b)
+	}
+	for i, c := range b {
+		switch c {
+		case '\r', '\n', '\v', '\f', 0xc2, 0xe2:
+			return i
+		}
+	}
+	return len(b)
+}
+
+//newlineSize returns size of newline at the beginning of b (0 if not newline).
+//It returns true if b is too short to determine.
+func (t *newlineTransformer) newlineSize(b []byte) (int, bool) {
+	switch b[0] {
+	case '\n':
+		return 1, false
+	case '\r':
+		if len(b) < 2 {
+			return 1, true
+		}
+		if b[1] == '\n' {
+			return 2, false
+		}
+		return 1, false
+	}
+	if !t.unicodeFlag {
+		return 0, false
+	}
+	for _, nl := range unicodeNewlines {
+		if n := len(nl); len(b) < n && bytes.HasPrefix([]byte(nl), b) {
+			return 0, true
+		} else if bytes.HasPrefix(b, []byte(nl)) {
+			return n, false
+		}
+	}
+	return 0, false
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,59 @@
+package pem
+
+import (
+	"sort"
+	"strings"
+)
+
+//procType is header key which must be first in PEM block (RFC 1421).
+const procType = "Proc-Type"
+
+//Block is a PEM encoded block.
+type Block struct {
+	Type    string            `json:"type"`              //type of block, e.g. "CERTIFICATE"
+	Headers map[string]string `json:"headers,omitempty"` //optional headers
+	Bytes   []byte            `json:"bytes"`             //decoded contents of block
+}
+
+//headerKeys returns keys of headers in order of output (Proc-Type first, and others sorted).
+func (b *Block) headerKeys() []string {
+	keys := make([]string, 0, len(b.Headers))
+	for k := range b.Headers {
+		if k != procType {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := b.Headers[procType]; ok {
+		keys = append([]string{procType}, keys...)
+	}
+	return keys
+}
+
+//validType returns true if type of block is printable ASCII without hyphen at both ends.
+func validType(t string) bool {
+	if len(t) == 0 || strings.HasPrefix(t, "-") || strings.HasSuffix(t, "-") {
+		return false
+	}
+	for _, c := range []byte(t) {
+		if c < 0x20 || 0x7e < c {
+			return false
+		}
+	}
+	return true
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,52 @@
+package pem_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/goark/gnkf/pem"
+)
+
+func ExampleEncode() {
+	output := &bytes.Buffer{}
+	if err := pem.Encode("MESSAGE", map[string]string{"Subject": "greeting"}, strings.NewReader("Hello World\n"), output); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(output.String())
+	//Output:
+	//-----BEGIN MESSAGE-----
+	//Subject: greeting
+	//
+	//SGVsbG8gV29ybGQK
+	//-----END MESSAGE-----
+}
+
+func ExampleDecode() {
+	blocks, err := pem.Decode(strings.NewReader("-----BEGIN MESSAGE-----\nSubject: greeting\n\nSGVsbG8gV29ybGQK\n-----END MESSAGE-----\n"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, b := range blocks {
+		fmt.Printf("%s %v %q\n", b.Type, b.Headers, b.Bytes)
+	}
+	//Output:
+	//MESSAGE map[Subject:greeting] "Hello World\n"
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,158 @@
+package pem
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/b64"
+	"github.com/goark/gnkf/ecode"
+)
+
+const (
+	beginPrefix    = "-----BEGIN "
+	endPrefix      = "-----END "
+	boundarySuffix = "-----"
+)
+
+//Encode outputs PEM block which wraps raw data.
+//Headers are output in order of Proc-Type first and others sorted.
+func Encode(blockType string, headers map[string]string, r io.Reader, w io.Writer) error {
+	if !validType(blockType) {
+		return errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithContext("type", blockType))
+	}
+	b := &Block{Type: blockType, Headers: headers}
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "%s%s%s\n", beginPrefix, blockType, boundarySuffix)
+	keys := b.headerKeys()
+	for _, k := range keys {
+		v := headers[k]
+		if len(k) == 0 || strings.ContainsAny(k, ":\r\n") || strings.ContainsAny(v, "\r\n") {
+			return errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithContext("type", blockType), errs.WithContext("header", k))
+		}
+		_, _ = fmt.Fprintf(buf, "%s: %s\n", k, v)
+	}
+	if len(keys) > 0 {
+		_ = buf.WriteByte('\n')
+	}
+	if _, err := buf.WriteTo(w); err != nil {
+		return errs.Wrap(err)
+	}
+	if err := b64.EncodeWithOptions(false, false, r, w, &b64.Options{LineLength: b64.PEMLineLength}); err != nil {
+		return errs.Wrap(err, errs.WithContext("type", blockType))
+	}
+	if _, err := fmt.Fprintf(w, "%s%s%s\n", endPrefix, blockType, boundarySuffix); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+//EncodeBlock outputs PEM block.
+func EncodeBlock(b *Block, w io.Writer) error {
+	if b == nil {
+		return errs.Wrap(ecode.ErrNullPointer)
+	}
+	return Encode(b.Type, b.Headers, bytes.NewReader(b.Bytes), w)
+}
+
+//Decode extracts and decodes all PEM blocks in the text.
+//Text outside of blocks (e.g. explanatory text of certificates) is ignored.
+func Decode(r io.Reader) ([]*Block, error) {
+	blocks := []*Block{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var (
+		block  *Block
+		body   *bytes.Buffer
+		inHead bool
+		key    string
+		lineNo int
+	)
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if block == nil {
+			if t, ok := boundary(line, beginPrefix); ok {
+				block, body, inHead, key = &Block{Type: t, Headers: map[string]string{}}, &bytes.Buffer{}, true, ""
+			}
+			continue
+		}
+		if t, ok := boundary(line, endPrefix); ok {
+			if t != block.Type {
+				return nil, errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithContext("type", block.Type), errs.WithContext("line", lineNo))
+			}
+			if err := b64.DecodeWithOptions(false, false, body, (*blockWriter)(block), &b64.Options{IgnoreSpace: true}); err != nil {
+				return nil, errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithCause(err), errs.WithContext("type", block.Type), errs.WithContext("line", lineNo))
+			}
+			if len(block.Headers) == 0 {
+				block.Headers = nil
+			}
+			blocks = append(blocks, block)
+			block = nil
+			continue
+		}
+		if inHead {
+			switch {
+			case len(line) == 0:
+				inHead = false
+			case len(key) > 0 && (line[0] == ' ' || line[0] == '\t'): //continuation of header
+				block.Headers[key] += " " + strings.TrimSpace(line)
+			case strings.Contains(line, ":"):
+				k, v, _ := strings.Cut(line, ":")
+				key = strings.TrimSpace(k)
+				block.Headers[key] = strings.TrimSpace(v)
+			default:
+				inHead = false
+				body.WriteString(line)
+			}
+			continue
+		}
+		body.WriteString(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if block != nil {
+		return nil, errs.Wrap(ecode.ErrInvalidPEMBlock, errs.WithContext("type", block.Type), errs.WithContext("line", lineNo))
+	}
+	if len(blocks) == 0 {
+		return nil, errs.Wrap(ecode.ErrNoPEMBlock)
+	}
+	return blocks, nil
+}
+
+//boundary returns type of block if line is boundary of block.
+func boundary(line, prefix string) (string, bool) {
+	if !strings.HasPrefix(line, prefix) || !strings.HasSuffix(line, boundarySuffix) || len(line) < len(prefix)+len(boundarySuffix) {
+		return "", false
+	}
+	t := line[len(prefix) : len(line)-len(boundarySuffix)]
+	return t, validType(t)
+}
+
+//blockWriter is io.Writer which appends decoded data to block.
+type blockWriter Block
+
+//Write method is implementation of io.Writer interface.
+func (bw *blockWriter) Write(p []byte) (int, error) {
+	bw.Bytes = append(bw.Bytes, p...)
+	return len(p), nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,113 @@
+package pem
+
+import (
+	"bytes"
+	stdpem "encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+func TestEncode(t *testing.T) {
+	testCases := []*Block{
+		{Type: "MESSAGE", Bytes: []byte("Hello World\n")},
+		{Type: "MESSAGE", Bytes: []byte{}},
+		{Type: "RSA PRIVATE KEY", Headers: map[string]string{"DEK-Info": "DES-EDE3-CBC,0123456789ABCDEF", "Proc-Type": "4,ENCRYPTED"}, Bytes: bytes.Repeat([]byte{0x00, 0x01, 0xfe, 0xff}, 50)},
+	}
+	for _, b := range testCases {
+		buf := &bytes.Buffer{}
+		if err := EncodeBlock(b, buf); err != nil {
+			t.Errorf("EncodeBlock(%+v) error = \"%+v\", want nil.", b, err)
+			continue
+		}
+		std := stdpem.EncodeToMemory(&stdpem.Block{Type: b.Type, Headers: b.Headers, Bytes: b.Bytes})
+		if buf.String() != string(std) {
+			t.Errorf("EncodeBlock(%+v) = %q, want %q.", b, buf.String(), std)
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	testCases := []*Block{
+		{Type: "", Bytes: []byte("Hello")},
+		{Type: "-FOO", Bytes: []byte("Hello")},
+		{Type: "FOO", Headers: map[string]string{"Key:": "value"}, Bytes: []byte("Hello")},
+		{Type: "FOO", Headers: map[string]string{"Key": "value\n"}, Bytes: []byte("Hello")},
+	}
+	for _, b := range testCases {
+		if err := EncodeBlock(b, &bytes.Buffer{}); !errs.Is(err, ecode.ErrInvalidPEMBlock) {
+			t.Errorf("EncodeBlock(%+v) error = \"%+v\", want \"%+v\".", b, err, ecode.ErrInvalidPEMBlock)
+		}
+	}
+	if err := EncodeBlock(nil, &bytes.Buffer{}); !errs.Is(err, ecode.ErrNullPointer) {
+		t.Errorf("EncodeBlock(nil) error = \"%+v\", want \"%+v\".", err, ecode.ErrNullPointer)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	bundle := &bytes.Buffer{}
+	bundle.WriteString("subject=CN = example\r\n")
+	src := []*stdpem.Block{
+		{Type: "CERTIFICATE", Bytes: bytes.Repeat([]byte("certificate"), 20)},
+		{Type: "RSA PRIVATE KEY", Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "DES-EDE3-CBC,0123456789ABCDEF"}, Bytes: []byte("private key")},
+		{Type: "MESSAGE", Bytes: nil},
+	}
+	for _, b := range src {
+		_ = stdpem.Encode(bundle, b)
+		bundle.WriteString("\n")
+	}
+	blocks, err := Decode(strings.NewReader(strings.ReplaceAll(bundle.String(), "\n", "\r\n")))
+	if err != nil {
+		t.Errorf("Decode() error = \"%+v\", want nil.", err)
+		return
+	}
+	if len(blocks) != len(src) {
+		t.Errorf("Decode() = %d blocks, want %d.", len(blocks), len(src))
+		return
+	}
+	for i, b := range blocks {
+		if b.Type != src[i].Type || !bytes.Equal(b.Bytes, src[i].Bytes) || len(b.Headers) != len(src[i].Headers) {
+			t.Errorf("Decode() [%d] = %+v, want %+v.", i, b, src[i])
+		}
+		for k, v := range src[i].Headers {
+			if b.Headers[k] != v {
+				t.Errorf("Decode() [%d] header %s = %q, want %q.", i, k, b.Headers[k], v)
+			}
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	testCases := []struct {
+		inp string
+		err error
+	}{
+		{inp: "", err: ecode.ErrNoPEMBlock},
+		{inp: "Hello World\n", err: ecode.ErrNoPEMBlock},
+		{inp: "-----BEGIN FOO-----\nSGVsbG8=\n-----END BAR-----\n", err: ecode.ErrInvalidPEMBlock},
+		{inp: "-----BEGIN FOO-----\nSGVsbG8=\n", err: ecode.ErrInvalidPEMBlock},
+		{inp: "-----BEGIN FOO-----\nSGVs!bG8=\n-----END FOO-----\n", err: ecode.ErrInvalidPEMBlock},
+	}
+	for _, tc := range testCases {
+		if _, err := Decode(strings.NewReader(tc.inp)); !errs.Is(err, tc.err) {
+			t.Errorf("Decode(%q) error = \"%+v\", want \"%+v\".", tc.inp, err, tc.err)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
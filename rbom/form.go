@@ -0,0 +1,99 @@
+package rbom
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+)
+
+//Form is type of BOM (byte order mark) for each encoding form of Unicode
+type Form int
+
+const (
+	UTF8    Form = iota //UTF-8 (default)
+	UTF16BE             //UTF-16 big endian
+	UTF16LE             //UTF-16 little endian
+	UTF32BE             //UTF-32 big endian
+	UTF32LE             //UTF-32 little endian
+)
+
+var formNamesMap = map[string]Form{
+	"utf-8":    UTF8,
+	"utf-16be": UTF16BE,
+	"utf-16le": UTF16LE,
+	"utf-32be": UTF32BE,
+	"utf-32le": UTF32LE,
+}
+
+var formBytesMap = map[Form][]byte{
+	UTF8:    {0xef, 0xbb, 0xbf},
+	UTF16BE: {0xfe, 0xff},
+	UTF16LE: {0xff, 0xfe},
+	UTF32BE: {0x00, 0x00, 0xfe, 0xff},
+	UTF32LE: {0xff, 0xfe, 0x00, 0x00},
+}
+
+func (f Form) String() string {
+	return formName(f)
+}
+
+//Bytes returns byte sequence of BOM.
+func (f Form) Bytes() []byte {
+	return append([]byte{}, formBytesMap[f]...)
+}
+
+func formName(f Form) string {
+	for key, value := range formNamesMap {
+		if value == f {
+			return key
+		}
+	}
+	return ""
+}
+
+//FormList returns list of BOM forms
+func FormList() []string {
+	return []string{
+		formName(UTF8),
+		formName(UTF16BE),
+		formName(UTF16LE),
+		formName(UTF32BE),
+		formName(UTF32LE),
+	}
+}
+
+//FormOf returns BOM form from name string
+func FormOf(name string) (Form, error) {
+	if f, ok := formNamesMap[strings.ToLower(name)]; ok {
+		return f, nil
+	}
+	return UTF8, errs.Wrap(ecode.ErrInvalidBomForm, errs.WithContext("name", name))
+}
+
+//DetectBom returns BOM form at the beginning of byte string.
+//UTF-32LE is checked before UTF-16LE because BOM of UTF-32LE starts with BOM of UTF-16LE.
+func DetectBom(b []byte) (Form, bool) {
+	for _, f := range []Form{UTF32BE, UTF32LE, UTF8, UTF16BE, UTF16LE} {
+		if bytes.HasPrefix(b, formBytesMap[f]) {
+			return f, true
+		}
+	}
+	return UTF8, false
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -10,9 +10,9 @@ import (
 	"github.com/goark/gnkf/ecode"
 )
 
-var bom = []byte{0xef, 0xbb, 0xbf}
+var bom = formBytesMap[UTF8]
 
-//RemoveBom removes BOM character in UTF-8 stream
+//RemoveBom removes BOM character in UTF-8 stream, or BOM at the beginning of UTF-16/32 stream
 func RemoveBom(r io.Reader) ([]byte, error) {
 	buf := bytes.Buffer{}
 	if _, err := buf.ReadFrom(r); err != nil {
@@ -21,11 +21,14 @@ func RemoveBom(r io.Reader) ([]byte, error) {
 	return RemoveBomBytes(buf.Bytes())
 }
 
-//RemoveBomBytes removes BOM character in UTF-8 byte string
+//RemoveBomBytes removes BOM character in UTF-8 byte string, or BOM at the beginning of UTF-16/32 byte string
 func RemoveBomBytes(b []byte) ([]byte, error) {
 	if len(b) == 0 {
 		return []byte{}, nil
 	}
+	if f, ok := DetectBom(b); ok && f != UTF8 {
+		return append([]byte{}, b[len(formBytesMap[f]):]...), nil
+	}
 	if !utf8.Valid(b) {
 		return nil, errs.Wrap(ecode.ErrInvalidUTF8Text)
 	}
@@ -40,7 +43,39 @@ func RemoveBomString(s string) string {
 	return strings.ReplaceAll(s, string(bom), "")
 }
 
-/* Copyright 2020 Spiegel
+//AddBom adds BOM at the beginning of text stream
+func AddBom(r io.Reader, f Form) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return AddBomBytes(buf.Bytes(), f)
+}
+
+//AddBomBytes adds BOM at the beginning of byte string.
+//It does nothing if the text has BOM of same form already.
+func AddBomBytes(b []byte, f Form) ([]byte, error) {
+	if ff, ok := DetectBom(b); ok {
+		if ff != f {
+			return nil, errs.Wrap(ecode.ErrMismatchBom, errs.WithContext("form", f.String()), errs.WithContext("bom", ff.String()))
+		}
+		return append([]byte{}, b...), nil
+	}
+	if f == UTF8 && !utf8.Valid(b) {
+		return nil, errs.Wrap(ecode.ErrInvalidUTF8Text)
+	}
+	return append(f.Bytes(), b...), nil
+}
+
+//AddBomString adds BOM character at the beginning of UTF-8 string
+func AddBomString(s string) string {
+	if strings.HasPrefix(s, string(bom)) {
+		return s
+	}
+	return string(bom) + s
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -2,8 +2,13 @@ package rbom_test
 
 import (
 	"bytes"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
 	"github.com/goark/gnkf/rbom"
 )
 
@@ -58,7 +63,134 @@ func TestRemoveBomString(t *testing.T) {
 	}
 }
 
-/* Copyright 2020 Spiegel
+func TestRemoveBomUTF16(t *testing.T) {
+	testCases := []struct {
+		inp  []byte
+		outp []byte
+	}{
+		{inp: []byte{0xfe, 0xff, 0x00, 0x48, 0xfe, 0xff}, outp: []byte{0x00, 0x48, 0xfe, 0xff}},
+		{inp: []byte{0xff, 0xfe, 0x48, 0x00, 0xff, 0xfe}, outp: []byte{0x48, 0x00, 0xff, 0xfe}},
+		{inp: []byte{0x00, 0x00, 0xfe, 0xff, 0x00, 0x00, 0x00, 0x48}, outp: []byte{0x00, 0x00, 0x00, 0x48}},
+		{inp: []byte{0xff, 0xfe, 0x00, 0x00, 0x48, 0x00, 0x00, 0x00}, outp: []byte{0x48, 0x00, 0x00, 0x00}},
+	}
+	for _, tc := range testCases {
+		if b, err := rbom.RemoveBomBytes(tc.inp); err != nil {
+			t.Errorf("RemoveBomBytes() = \"%v\", want nil.", err)
+		} else if !bytes.Equal(b, tc.outp) {
+			t.Errorf("RemoveBomBytes() = %v, want %v.", b, tc.outp)
+		}
+	}
+}
+
+func TestDetectBom(t *testing.T) {
+	testCases := []struct {
+		inp  []byte
+		form rbom.Form
+		ok   bool
+	}{
+		{inp: nil, form: rbom.UTF8, ok: false},
+		{inp: []byte("Hello"), form: rbom.UTF8, ok: false},
+		{inp: []byte{0xef, 0xbb, 0xbf, 0x48}, form: rbom.UTF8, ok: true},
+		{inp: []byte{0xfe, 0xff, 0x00, 0x48}, form: rbom.UTF16BE, ok: true},
+		{inp: []byte{0xff, 0xfe, 0x48, 0x00}, form: rbom.UTF16LE, ok: true},
+		{inp: []byte{0x00, 0x00, 0xfe, 0xff}, form: rbom.UTF32BE, ok: true},
+		{inp: []byte{0xff, 0xfe, 0x00, 0x00}, form: rbom.UTF32LE, ok: true},
+	}
+	for _, tc := range testCases {
+		if f, ok := rbom.DetectBom(tc.inp); f != tc.form || ok != tc.ok {
+			t.Errorf("DetectBom(%v) = %v, %v, want %v, %v.", tc.inp, f, ok, tc.form, tc.ok)
+		}
+	}
+}
+
+func TestFormList(t *testing.T) {
+	res := "utf-8|utf-16be|utf-16le|utf-32be|utf-32le"
+	str := strings.Join(rbom.FormList(), "|")
+	if str != res {
+		t.Errorf("FormList() = \"%+v\", want \"%+v\".", str, res)
+	}
+	for _, name := range rbom.FormList() {
+		if f, err := rbom.FormOf(strings.ToUpper(name)); err != nil || f.String() != name {
+			t.Errorf("FormOf(%s) = \"%+v\", %v.", name, f, err)
+		}
+	}
+	if _, err := rbom.FormOf("foo"); !errs.Is(err, ecode.ErrInvalidBomForm) {
+		t.Errorf("FormOf(foo) error = \"%+v\", want \"%+v\".", err, ecode.ErrInvalidBomForm)
+	}
+}
+
+func TestAddBom(t *testing.T) {
+	testCases := []struct {
+		inp  []byte
+		form rbom.Form
+		outp []byte
+		err  error
+	}{
+		{inp: nil, form: rbom.UTF8, outp: []byte{0xef, 0xbb, 0xbf}, err: nil},
+		{inp: []byte("Hello"), form: rbom.UTF8, outp: []byte{0xef, 0xbb, 0xbf, 0x48, 0x65, 0x6c, 0x6c, 0x6f}, err: nil},
+		{inp: []byte{0xef, 0xbb, 0xbf, 0x48}, form: rbom.UTF8, outp: []byte{0xef, 0xbb, 0xbf, 0x48}, err: nil},
+		{inp: []byte{0x48, 0x00}, form: rbom.UTF16LE, outp: []byte{0xff, 0xfe, 0x48, 0x00}, err: nil},
+		{inp: []byte{0x00, 0x00, 0x00, 0x48}, form: rbom.UTF32BE, outp: []byte{0x00, 0x00, 0xfe, 0xff, 0x00, 0x00, 0x00, 0x48}, err: nil},
+		{inp: []byte{0xfe, 0xff, 0x00, 0x48}, form: rbom.UTF8, outp: nil, err: ecode.ErrMismatchBom},
+		{inp: []byte{0x48, 0xff}, form: rbom.UTF8, outp: nil, err: ecode.ErrInvalidUTF8Text},
+	}
+	for _, tc := range testCases {
+		b, err := rbom.AddBom(bytes.NewReader(tc.inp), tc.form)
+		if !errs.Is(err, tc.err) {
+			t.Errorf("AddBom(%v, %v) error = \"%+v\", want \"%+v\".", tc.inp, tc.form, err, tc.err)
+		} else if !bytes.Equal(b, tc.outp) {
+			t.Errorf("AddBom(%v, %v) = %v, want %v.", tc.inp, tc.form, b, tc.outp)
+		}
+	}
+}
+
+func TestAddBomString(t *testing.T) {
+	testCases := []struct {
+		inp  string
+		outp string
+	}{
+		{inp: "", outp: "\ufeff"},
+		{inp: "Hello", outp: "\ufeffHello"},
+		{inp: "\ufeffHello", outp: "\ufeffHello"},
+	}
+	for _, tc := range testCases {
+		if s := rbom.AddBomString(tc.inp); s != tc.outp {
+			t.Errorf("AddBomString(%q) = %q, want %q.", tc.inp, s, tc.outp)
+		}
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	testCases := []struct {
+		inp  string
+		opts *rbom.Options
+		outp string
+	}{
+		{inp: "", opts: nil, outp: ""},
+		{inp: "\ufeff", opts: nil, outp: ""},
+		{inp: "Hello", opts: nil, outp: "Hello"},
+		{inp: "\ufeffHel\ufefflo", opts: nil, outp: "Hel\ufefflo"},
+		{inp: "\ufeffHel\ufefflo", opts: &rbom.Options{All: true}, outp: "Hello"},
+		{inp: "Hel\ufeff\ufefflo\ufeff", opts: &rbom.Options{All: true}, outp: "Hello"},
+		{inp: "\xff\xfeH\x00", opts: nil, outp: "H\x00"},
+		{inp: "\xff\xfea\x00\xff\xfe", opts: &rbom.Options{All: true}, outp: "a\x00\xff\xfe"},
+		{inp: "\xfe\xff\x00a\xfe\xff", opts: &rbom.Options{All: true}, outp: "\x00a\xfe\xff"},
+		{inp: "\xff\xfe\xef\xbb\xbf\x00", opts: &rbom.Options{All: true}, outp: "\xef\xbb\xbf\x00"},
+		{inp: "a\xffb\xef\xbb\xbfc\xef\xbb", opts: &rbom.Options{All: true}, outp: "a\xffbc\xef\xbb"},
+	}
+	for _, tc := range testCases {
+		for _, r := range []io.Reader{strings.NewReader(tc.inp), iotest.OneByteReader(strings.NewReader(tc.inp))} {
+			b, err := io.ReadAll(rbom.NewReaderWithOptions(r, tc.opts))
+			if err != nil {
+				t.Errorf("NewReaderWithOptions(%q, %+v) error = \"%+v\", want nil.", tc.inp, tc.opts, err)
+			} else if string(b) != tc.outp {
+				t.Errorf("NewReaderWithOptions(%q, %+v) = %q, want %q.", tc.inp, tc.opts, b, tc.outp)
+			}
+		}
+	}
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
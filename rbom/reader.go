@@ -0,0 +1,112 @@
+package rbom
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/goark/errs"
+	"golang.org/x/text/transform"
+)
+
+//Options is options of removing BOM in text stream
+type Options struct {
+	All bool //remove all U+FEFF (zero width no-break space) in UTF-8 text, not only leading BOM (ignored in UTF-16/32 text with BOM)
+}
+
+func (opts *Options) all() bool {
+	if opts == nil {
+		return false
+	}
+	return opts.All
+}
+
+//Reader is io.Reader which removes BOM at the beginning of text stream.
+type Reader struct {
+	r       io.Reader
+	checked bool
+	wide    bool //BOM of UTF-16 or UTF-32 is found
+}
+
+var _ io.Reader = (*Reader)(nil)
+
+//NewReader returns io.Reader which removes only BOM at the beginning of text stream.
+//U+FEFF (zero width no-break space) inside the text is kept.
+func NewReader(r io.Reader) io.Reader {
+	return NewReaderWithOptions(r, nil)
+}
+
+//NewReaderWithOptions returns io.Reader which removes BOM in text stream with options.
+func NewReaderWithOptions(r io.Reader, opts *Options) io.Reader {
+	rd := &Reader{r: r}
+	if opts.all() {
+		return transform.NewReader(rd, &feffRemover{rd: rd})
+	}
+	return rd
+}
+
+//Read method is implementation of io.Reader interface.
+func (rd *Reader) Read(p []byte) (int, error) {
+	if !rd.checked {
+		rd.checked = true
+		head := make([]byte, len(formBytesMap[UTF32BE]))
+		n, err := io.ReadFull(rd.r, head)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, errs.Wrap(err)
+		}
+		head = head[:n]
+		if f, ok := DetectBom(head); ok {
+			head = head[len(formBytesMap[f]):]
+			rd.wide = f != UTF8
+		}
+		rd.r = io.MultiReader(bytes.NewReader(head), rd.r)
+	}
+	return rd.r.Read(p)
+}
+
+//feffRemover is transform.Transformer which removes U+FEFF in UTF-8 text.
+//Other bytes (including invalid UTF-8 sequences) are passed through unchanged, and the text of UTF-16/32 with BOM is not changed.
+type feffRemover struct {
+	transform.NopResetter
+	rd *Reader
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *feffRemover) Transform(dst, src []byte, atEOF bool) (int, int, error) {
+	nDst, nSrc := 0, 0
+	bom := formBytesMap[UTF8]
+	for nSrc < len(src) {
+		if !t.rd.wide && src[nSrc] == bom[0] {
+			rest := src[nSrc:]
+			if bytes.HasPrefix(rest, bom) {
+				nSrc += len(bom)
+				continue
+			}
+			if !atEOF && len(rest) < len(bom) && bytes.HasPrefix(bom, rest) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+		}
+		if nDst >= len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		dst[nDst] = src[nSrc]
+		nDst++
+		nSrc++
+	}
+	return nDst, nSrc, nil
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,35 @@
+package repair_test
+
+import (
+	"fmt"
+
+	"github.com/goark/gnkf/repair"
+)
+
+func ExampleRepairString() {
+	s, res, err := repair.RepairString("ã“ã‚“ã«ã¡ã¯", nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(s)
+	fmt.Println(res)
+	//Output:
+	//こんにちは
+	//UTF-8 decoded as windows-1252 (plausibility: 0.00 -> 1.00, unrecovered: 0)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,42 @@
+package repair
+
+//DefaultCharsets is default list of character encodings wrongly used for decoding UTF-8 text.
+var DefaultCharsets = []string{"windows-1252", "ISO-8859-1", "Shift_JIS", "EUC-JP"}
+
+//DefaultMaxDepth is default maximum depth of mojibake chain.
+const DefaultMaxDepth = 2
+
+//Options is options of repairing mojibake
+type Options struct {
+	MaxDepth int      //maximum depth of mojibake chain (default: DefaultMaxDepth)
+	Charsets []string //candidates of character encodings wrongly used for decoding (default: DefaultCharsets)
+}
+
+func (opts *Options) maxDepth() int {
+	if opts == nil || opts.MaxDepth <= 0 {
+		return DefaultMaxDepth
+	}
+	return opts.MaxDepth
+}
+
+func (opts *Options) charsets() []string {
+	if opts == nil || len(opts.Charsets) == 0 {
+		return DefaultCharsets
+	}
+	return opts.Charsets
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,164 @@
+package repair
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+	"github.com/goark/gnkf/enc"
+	"github.com/goark/gnkf/guess"
+	"golang.org/x/text/encoding"
+)
+
+const (
+	minGain       = 0.1 //minimum gain of plausibility by repair
+	minConfidence = 90  //minimum confidence of guessing original encoding (not UTF-8)
+)
+
+//candidate is a candidate of a step of repair.
+type candidate struct {
+	text        string
+	step        Step
+	unrecovered int
+	score       float64
+}
+
+//Repair repairs mojibake in UTF-8 text stream, and writes repaired text.
+func Repair(writer io.Writer, txt io.Reader, opts *Options) (*Result, error) {
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(txt); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	s, res, err := RepairString(buf.String(), opts)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if _, err := io.WriteString(writer, s); err != nil {
+		return res, errs.Wrap(err)
+	}
+	return res, nil
+}
+
+//RepairString repairs mojibake in UTF-8 string.
+//Mojibake is UTF-8 text (or text in Japanese encoding) wrongly decoded as other encoding, and re-encoded in UTF-8.
+//It tries chains of reverse conversions by candidate encodings up to Options.MaxDepth, and takes the most plausible result.
+//Intermediate text in a chain may be less plausible (e.g. double mojibake).
+func RepairString(s string, opts *Options) (string, *Result, error) {
+	if !utf8.ValidString(s) {
+		return "", nil, errs.Wrap(ecode.ErrInvalidUTF8Text)
+	}
+	charsets := opts.charsets()
+	encs := make([]encoding.Encoding, 0, len(charsets))
+	for _, cs := range charsets {
+		e, err := enc.Encoding(cs)
+		if err != nil {
+			return "", nil, errs.Wrap(err, errs.WithContext("charset", cs))
+		}
+		encs = append(encs, e)
+	}
+	res := &Result{Chain: []Step{}, Before: plausibility(s)}
+	res.After = res.Before
+	text := s
+	seen := map[string]bool{s: true}
+	var search func(s string, chain []Step, unrecovered, depth int)
+	search = func(s string, chain []Step, unrecovered, depth int) {
+		if depth >= opts.maxDepth() {
+			return
+		}
+		for i, e := range encs {
+			c := tryStep(s, charsets[i], e)
+			if c == nil || seen[c.text] {
+				continue
+			}
+			seen[c.text] = true
+			steps := append([]Step{c.step}, chain...)
+			n := unrecovered + c.unrecovered
+			//shorter chain is taken if plausibility is the same
+			if c.score >= res.Before+minGain && (c.score > res.After || (c.score == res.After && len(steps) < len(res.Chain))) {
+				text = c.text
+				res.Chain, res.Unrecovered, res.After = steps, n, c.score
+			}
+			search(c.text, steps, n, depth+1)
+		}
+	}
+	search(s, []Step{}, 0, 0)
+	return text, res, nil
+}
+
+//tryStep tries reverse conversion of mojibake by the encoding wrongly used for decoding.
+func tryStep(s, charset string, e encoding.Encoding) *candidate {
+	seq, ok := reverse(s, e)
+	if !ok {
+		return nil
+	}
+	fill, insert := fillers(charset)
+	if b, n, ok := restore(seq, fill, insert); ok {
+		if t := string(b); t != s {
+			return &candidate{text: t, step: Step{Original: "UTF-8", Decoded: charset}, unrecovered: n, score: plausibility(t)}
+		}
+		return nil
+	}
+	//original text is not UTF-8
+	if isJapanese(e) && !hasMojibakeSign(s) {
+		return nil //Japanese text reinterpreted by other Japanese encoding is also plausible
+	}
+	b := make([]byte, 0, len(seq))
+	for _, c := range seq {
+		if c == hole {
+			return nil
+		}
+		b = append(b, byte(c))
+	}
+	gs, err := guess.DetectBytesWithOptions(b, &guess.Options{Strategy: guess.StrategyJapanese})
+	if err != nil || len(gs) == 0 || gs[0].Confidence < minConfidence || gs[0].Charset == "UTF-8" || strings.EqualFold(gs[0].Charset, charset) {
+		return nil
+	}
+	oe, err := enc.Encoding(gs[0].Charset)
+	if err != nil {
+		return nil
+	}
+	tb, err := oe.NewDecoder().Bytes(b)
+	if err != nil {
+		return nil
+	}
+	t := string(tb)
+	if t == s {
+		return nil
+	}
+	n := strings.Count(t, "\ufffd") - strings.Count(s, "\ufffd")
+	if n < 0 {
+		n = 0
+	}
+	return &candidate{text: t, step: Step{Original: gs[0].Charset, Decoded: charset}, unrecovered: n, score: plausibility(t)}
+}
+
+//isJapanese returns true if the encoding can carry Japanese characters (Shift_JIS, EUC-JP, ...).
+func isJapanese(e encoding.Encoding) bool {
+	_, err := e.NewEncoder().String("あ")
+	return err == nil
+}
+
+//hasMojibakeSign returns true if the text includes U+FFFD or C1 control characters.
+func hasMojibakeSign(s string) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return r == utf8.RuneError || (0x80 <= r && r < 0xa0)
+	})
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,153 @@
+package repair
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goark/errs"
+	"github.com/goark/gnkf/ecode"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func TestRepairString(t *testing.T) {
+	src := "こんにちは、世界！テストです。私の名前は Spiegel です。"
+	latin1, _ := charmap.ISO8859_1.NewDecoder().String(src)
+	sjis, _ := japanese.ShiftJIS.NewDecoder().String("こんにちは")
+	latin1x2, _ := charmap.ISO8859_1.NewDecoder().String(latin1) //double mojibake
+	testCases := []struct {
+		inp   string
+		outp  string
+		chain []Step
+	}{
+		{inp: latin1, outp: src, chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}}},
+		{inp: "ã“ã‚“ã«ã¡ã¯", outp: "こんにちは", chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}}},
+		{inp: sjis, outp: "こんにちは", chain: []Step{{Original: "UTF-8", Decoded: "Shift_JIS"}}},
+		{inp: src, outp: src, chain: []Step{}},
+		{inp: "Hello, world", outp: "Hello, world", chain: []Step{}},
+		{inp: "café crème", outp: "café crème", chain: []Step{}},
+		{inp: "Привет мир", outp: "Привет мир", chain: []Step{}},
+		{inp: "中文简体字", outp: "中文简体字", chain: []Step{}},
+		{inp: "cafÃ© crÃ¨me", outp: "café crème", chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}}},
+		{inp: "GrÃ¶ÃŸe und MaÃŸe", outp: "Größe und Maße", chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}}},
+		{inp: "ÃœnÃ¯cÃ¶dÃ©", outp: "Ünïcödé", chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}}},
+		{inp: "譚ｱ莠ｬ驛ｽ", outp: "東京都", chain: []Step{{Original: "UTF-8", Decoded: "Shift_JIS"}}},
+		{inp: "Größe und Maße", outp: "Größe und Maße", chain: []Step{}},
+		{inp: "東京都", outp: "東京都", chain: []Step{}},
+		{inp: "ｱｲｳｴｵ", outp: "ｱｲｳｴｵ", chain: []Step{}},
+		{inp: "ｶﾀｶﾅ", outp: "ｶﾀｶﾅ", chain: []Step{}},
+		{inp: "ﾃｽﾄ", outp: "ﾃｽﾄ", chain: []Step{}},
+		{inp: "ﾃｽﾄです。", outp: "ﾃｽﾄです。", chain: []Step{}},
+		{inp: latin1x2, outp: src, chain: []Step{{Original: "UTF-8", Decoded: "windows-1252"}, {Original: "UTF-8", Decoded: "windows-1252"}}},
+	}
+	for _, tc := range testCases {
+		s, res, err := RepairString(tc.inp, nil)
+		if err != nil {
+			t.Errorf("RepairString(%q) error = \"%+v\", want nil.", tc.inp, err)
+			continue
+		}
+		if s != tc.outp {
+			t.Errorf("RepairString(%q) = %q, want %q.", tc.inp, s, tc.outp)
+		}
+		if len(res.Chain) != len(tc.chain) {
+			t.Errorf("RepairString(%q) chain = %v, want %v.", tc.inp, res.Chain, tc.chain)
+			continue
+		}
+		for i, st := range res.Chain {
+			if st != tc.chain[i] {
+				t.Errorf("RepairString(%q) chain = %v, want %v.", tc.inp, res.Chain, tc.chain)
+				break
+			}
+		}
+		if res.Repaired() != (len(tc.chain) > 0) {
+			t.Errorf("Result.Repaired(%q) = %v, want %v.", tc.inp, res.Repaired(), len(tc.chain) > 0)
+		}
+	}
+}
+
+func TestRepairRoundTrip(t *testing.T) {
+	//mojibake without lost bytes
+	testCases := []struct {
+		src     string
+		decoder encoding.Encoding
+		charset string
+	}{
+		{src: "café crème brûlée", decoder: charmap.Windows1252, charset: "windows-1252"},
+		{src: "Größe und Maße, naïve Ünïcödé", decoder: charmap.Windows1252, charset: "windows-1252"},
+		{src: "It’s ‘quoted’ — 10 €", decoder: charmap.Windows1252, charset: "windows-1252"},
+		{src: "東京都", decoder: japanese.ShiftJIS, charset: "Shift_JIS"},
+		{src: "こんにちは世界", decoder: japanese.ShiftJIS, charset: "Shift_JIS"},
+		{src: "ひらがな", decoder: japanese.ShiftJIS, charset: "Shift_JIS"},
+	}
+	for _, tc := range testCases {
+		inp, err := tc.decoder.NewDecoder().String(tc.src)
+		if err != nil {
+			t.Errorf("Decoder.String(%q) error = \"%+v\", want nil.", tc.src, err)
+			continue
+		}
+		s, res, err := RepairString(inp, nil)
+		if err != nil {
+			t.Errorf("RepairString(%q) error = \"%+v\", want nil.", inp, err)
+			continue
+		}
+		if s != tc.src {
+			t.Errorf("RepairString(%q) = %q, want %q.", inp, s, tc.src)
+		}
+		if want := []Step{{Original: "UTF-8", Decoded: tc.charset}}; len(res.Chain) != 1 || res.Chain[0] != want[0] {
+			t.Errorf("RepairString(%q) chain = %v, want %v.", inp, res.Chain, want)
+		}
+	}
+}
+
+func TestRepairUnrecovered(t *testing.T) {
+	src := "こんにちは、世界！テストです。"
+	inp, _ := charmap.Windows1252.NewDecoder().String(src) //undefined bytes are replaced by U+FFFD
+	s, res, err := RepairString(inp, nil)
+	if err != nil {
+		t.Errorf("RepairString(%q) error = \"%+v\", want nil.", inp, err)
+		return
+	}
+	if !res.Repaired() || res.After <= res.Before {
+		t.Errorf("RepairString(%q) result = %v, want repaired.", inp, res)
+	}
+	if n := strings.Count(s, "�"); n != res.Unrecovered {
+		t.Errorf("RepairString(%q) = %q, unrecovered %d, want %d.", inp, s, res.Unrecovered, n)
+	}
+	if !strings.HasPrefix(s, "こんにちは、世界") {
+		t.Errorf("RepairString(%q) = %q, want prefix %q.", inp, s, "こんにちは、世界")
+	}
+}
+
+func TestRepairError(t *testing.T) {
+	testCases := []struct {
+		inp  string
+		opts *Options
+		err  error
+	}{
+		{inp: "\xff\xfe", opts: nil, err: ecode.ErrInvalidUTF8Text},
+		{inp: "abc", opts: &Options{Charsets: []string{"foo"}}, err: ecode.ErrNotSuppotEncoding},
+	}
+	for _, tc := range testCases {
+		buf := &bytes.Buffer{}
+		if _, err := Repair(buf, strings.NewReader(tc.inp), tc.opts); !errs.Is(err, tc.err) {
+			t.Errorf("Repair(%q) error = \"%+v\", want \"%+v\".", tc.inp, err, tc.err)
+		}
+	}
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -0,0 +1,378 @@
+package repair
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+)
+
+//hole is a lost byte in reversed byte sequence.
+const hole = -1
+
+//fillerBytes is table of candidates for lost bytes in each character encoding.
+//These bytes are undefined in the encoding, so they are often replaced by U+FFFD or deleted.
+var fillerBytes = map[string][]byte{
+	"windows-1252": {0x81, 0x8d, 0x8f, 0x90, 0x9d},
+	"cp1252":       {0x81, 0x8d, 0x8f, 0x90, 0x9d},
+}
+
+//fillers returns candidates for lost bytes, and true if lost bytes may be deleted in the text.
+func fillers(charset string) ([]byte, bool) {
+	if fill, ok := fillerBytes[strings.ToLower(charset)]; ok {
+		return fill, true
+	}
+	fill := make([]byte, 0, 0x40)
+	for c := 0x80; c < 0xc0; c++ {
+		fill = append(fill, byte(c))
+	}
+	return fill, false
+}
+
+//reverse encodes the text by the encoding which was wrongly used for decoding.
+//U+FFFD (lost byte) is replaced by hole, and C1 control characters are passed through.
+func reverse(s string, e encoding.Encoding) ([]int, bool) {
+	encoder := e.NewEncoder()
+	cache := map[rune][]byte{}
+	seq := make([]int, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == utf8.RuneError:
+			seq = append(seq, hole)
+			continue
+		case r < utf8.RuneSelf:
+			seq = append(seq, int(r))
+			continue
+		}
+		b, ok := cache[r]
+		if !ok {
+			var err error
+			if b, err = encoder.Bytes([]byte(string(r))); err != nil {
+				if r >= 0xa0 {
+					return nil, false
+				}
+				b = []byte{byte(r)}
+			}
+			cache[r] = b
+		}
+		for _, c := range b {
+			seq = append(seq, int(c))
+		}
+	}
+	return seq, true
+}
+
+//restore restores UTF-8 byte sequence from reversed byte sequence, filling holes.
+//A hole may be two bytes if the encoding is multibyte, and lost bytes deleted in the text are also filled if insert is true.
+//It returns count of characters which cannot be restored (replaced by U+FFFD),
+//and false if the sequence is not UTF-8.
+func restore(seq []int, fill []byte, insert bool) ([]byte, int, bool) {
+	out := make([]byte, 0, len(seq))
+	unrecovered := 0
+	weights := map[rune]int{}
+	for i := 0; i < len(seq); {
+		c := seq[i]
+		switch {
+		case c == hole: //lost leading byte
+			for i++; i < len(seq) && isContinuation(seq[i]); i++ {
+			}
+			out = append(out, "\ufffd"...)
+			unrecovered++
+			continue
+		case c < utf8.RuneSelf:
+			out = append(out, byte(c))
+			i++
+			continue
+		}
+		n := continuationLen(byte(c))
+		if n == 0 && i > 0 && seq[i-1] == hole {
+			//leading byte is lost in the hole of the previous character
+			present := []int{}
+			for ; len(present) < utf8.UTFMax-1 && i < len(seq) && isContinuation(seq[i]); i++ {
+				present = append(present, seq[i])
+			}
+			if r, ok := fillLead(present, weights); ok {
+				out = utf8.AppendRune(out, r)
+			} else {
+				out = append(out, "\ufffd"...)
+				unrecovered++
+			}
+			continue
+		}
+		if n == 0 {
+			return nil, 0, false
+		}
+		present := []int{}
+		holes := 0
+		for i++; len(present) < n && i < len(seq) && (seq[i] == hole || isContinuation(seq[i])); i++ {
+			present = append(present, seq[i])
+			if seq[i] == hole {
+				holes++
+			}
+		}
+		if short := n - len(present); short > 1 || (short > 0 && !insert && holes == 0) {
+			return nil, 0, false
+		} else if short == 0 && holes == 0 {
+			//no lost bytes (control characters in intermediate text of chain are kept)
+			b := []byte{byte(c)}
+			for _, p := range present {
+				b = append(b, byte(p))
+			}
+			r, size := utf8.DecodeRune(b)
+			if r == utf8.RuneError || size != len(b) {
+				return nil, 0, false
+			}
+			out = utf8.AppendRune(out, r)
+			continue
+		}
+		if r, ok := fillRune(c, present, n, fill, weights); ok {
+			out = utf8.AppendRune(out, r)
+		} else {
+			out = append(out, "\ufffd"...)
+			unrecovered++
+		}
+	}
+	return out, unrecovered, true
+}
+
+func isContinuation(c int) bool {
+	return 0x80 <= c && c < 0xc0
+}
+
+//continuationLen returns count of continuation bytes for leading byte in UTF-8 (0 if invalid).
+func continuationLen(c byte) int {
+	switch {
+	case 0xc2 <= c && c < 0xe0:
+		return 1
+	case 0xe0 <= c && c < 0xf0:
+		return 2
+	case 0xf0 <= c && c < 0xf5:
+		return 3
+	}
+	return 0
+}
+
+//fillRune fills holes (and lost bytes) in a character of UTF-8 by the most plausible bytes.
+//It returns false if no plausible character or two or more characters are equally plausible.
+func fillRune(lead int, present []int, n int, fill []byte, weights map[rune]int) (rune, bool) {
+	best, bestWeight, tie := utf8.RuneError, 0, false
+	for _, slots := range arrangements(present, n) {
+		holes := []int{}
+		b := make([]byte, len(slots)+1)
+		b[0] = byte(lead)
+		for i, c := range slots {
+			if c == hole {
+				holes = append(holes, i+1)
+			} else {
+				b[i+1] = byte(c)
+			}
+		}
+		if len(holes) > 2 {
+			continue
+		}
+		combos := 1
+		for range holes {
+			combos *= len(fill)
+		}
+		for k := 0; k < combos; k++ {
+			x := k
+			for _, h := range holes {
+				b[h] = fill[x%len(fill)]
+				x /= len(fill)
+			}
+			r, size := utf8.DecodeRune(b)
+			if r == utf8.RuneError || size != len(b) || r == best {
+				continue
+			}
+			w, ok := weights[r]
+			if !ok {
+				w = runeWeight(r)
+				weights[r] = w
+			}
+			switch {
+			case w > bestWeight:
+				best, bestWeight, tie = r, w, false
+			case w == bestWeight:
+				tie = true
+			}
+		}
+	}
+	return best, bestWeight > 0 && !tie
+}
+
+//fillLead fills lost leading byte of a character of UTF-8 by the most plausible byte.
+//It returns false if no plausible character or two or more characters are equally plausible.
+func fillLead(present []int, weights map[rune]int) (rune, bool) {
+	best, bestWeight, tie := utf8.RuneError, 0, false
+	b := make([]byte, len(present)+1)
+	for i, c := range present {
+		b[i+1] = byte(c)
+	}
+	for lead := 0xc2; lead < 0xf5; lead++ {
+		if continuationLen(byte(lead)) != len(present) {
+			continue
+		}
+		b[0] = byte(lead)
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError || size != len(b) {
+			continue
+		}
+		w, ok := weights[r]
+		if !ok {
+			w = runeWeight(r)
+			weights[r] = w
+		}
+		switch {
+		case w > bestWeight:
+			best, bestWeight, tie = r, w, false
+		case w == bestWeight:
+			tie = true
+		}
+	}
+	return best, bestWeight > 0 && !tie
+}
+
+//arrangements returns all arrangements of n continuation bytes, inserting holes into present bytes.
+func arrangements(present []int, n int) [][]int {
+	if len(present) >= n {
+		return [][]int{present}
+	}
+	list := [][]int{}
+	for i := 0; i <= len(present); i++ {
+		slots := append(append(append([]int{}, present[:i]...), hole), present[i:]...)
+		list = append(list, arrangements(slots, n)...)
+	}
+	return list
+}
+
+//runeWeight returns weight of character in Japanese text.
+func runeWeight(r rune) int {
+	switch {
+	case 0x80 <= r && r < 0xa0, !unicode.IsGraphic(r), unicode.Is(unicode.Co, r):
+		return 0 //control, unassigned and private use characters
+	case 0x3041 <= r && r <= 0x30ff, r == 0x3001, r == 0x3002, 0xff61 <= r && r <= 0xff9f: //hiragana, katakana (including half-width) and punctuations
+		return 4
+	case 0x4e00 <= r && r <= 0x9fff:
+		switch jisKanjiLevel(r) {
+		case 1:
+			return 3
+		case 2:
+			return 2
+		}
+	case 0x3000 <= r && r <= 0x303f, 0xff01 <= r && r <= 0xff5e: //CJK symbols and full-width forms
+		return 2
+	}
+	return 1
+}
+
+//jisKanjiLevel returns level of kanji in JIS X 0208 (0 if not in JIS X 0208).
+func jisKanjiLevel(r rune) int {
+	b, err := japanese.ShiftJIS.NewEncoder().Bytes([]byte(string(r)))
+	if err != nil || len(b) != 2 {
+		return 0
+	}
+	switch code := int(b[0])<<8 | int(b[1]); {
+	case 0x889f <= code && code <= 0x9872:
+		return 1
+	case 0x989f <= code && code <= 0xeaa4:
+		return 2
+	}
+	return 0
+}
+
+//signEncodings is list of encodings in which mojibake signatures are found.
+var signEncodings = []encoding.Encoding{charmap.Windows1252, japanese.ShiftJIS}
+
+//plausibility returns plausibility of the text (0 to 1), by ratio of non-ASCII characters which are not mojibake signatures.
+//Mojibake signatures are U+FFFD, control characters, and characters whose bytes in windows-1252 or Shift_JIS
+//form UTF-8 multibyte sequence (e.g. "Ã©", "â€™" and "譚ｱ").
+func plausibility(s string) float64 {
+	rs := []rune(s)
+	signs := make([]bool, len(rs))
+	for _, e := range signEncodings {
+		markSigns(rs, e, signs)
+	}
+	n, bad := 0, 0
+	for i, r := range rs {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		n++
+		if signs[i] || r == utf8.RuneError || runeWeight(r) == 0 {
+			bad++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return float64(n-bad) / float64(n)
+}
+
+//markSigns marks characters whose bytes in the encoding form a run of UTF-8 multibyte sequences.
+//The run must start and end at character boundaries, and truncated sequences (lost bytes) are allowed in it.
+func markSigns(rs []rune, e encoding.Encoding, signs []bool) {
+	encoder := e.NewEncoder()
+	cache := map[rune][]byte{}
+	b, owner, start := []byte{}, []int{}, []bool{}
+	for i, r := range rs {
+		bs, ok := cache[r]
+		if !ok {
+			var err error
+			if bs, err = encoder.Bytes([]byte(string(r))); err != nil {
+				bs = []byte{0} //unencodable character breaks the run
+				if 0x80 <= r && r < 0xa0 {
+					bs = []byte{byte(r)} //C1 control characters
+				}
+			}
+			cache[r] = bs
+		}
+		for k, c := range bs {
+			b, owner, start = append(b, c), append(owner, i), append(start, k == 0)
+		}
+	}
+	runStart, runEnd := -1, -1
+	mark := func() {
+		for p := runStart; 0 <= p && p < runEnd; p++ {
+			signs[owner[p]] = true
+		}
+		runStart, runEnd = -1, -1
+	}
+	for p := 0; p < len(b); {
+		n := continuationLen(b[p])
+		q := p + 1
+		for n > 0 && q < len(b) && q-p <= n && isContinuation(int(b[q])) {
+			q++
+		}
+		if q == p+1 { //not a multibyte sequence
+			mark()
+			p++
+			continue
+		}
+		if runStart < 0 && start[p] {
+			runStart = p
+		}
+		if runStart >= 0 && (q == len(b) || start[q]) {
+			runEnd = q
+		}
+		p = q
+	}
+	mark()
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
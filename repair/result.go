@@ -0,0 +1,56 @@
+package repair
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Step is a step of mojibake: text in Original encoding was wrongly decoded as Decoded encoding, and re-encoded in UTF-8.
+type Step struct {
+	Original string `json:"original"`
+	Decoded  string `json:"decoded"`
+}
+
+func (s Step) String() string {
+	return s.Original + " decoded as " + s.Decoded
+}
+
+//Result is result of repairing mojibake
+type Result struct {
+	Chain       []Step  `json:"chain"`       //steps of mojibake in order of occurrence (empty if no mojibake)
+	Unrecovered int     `json:"unrecovered"` //count of lost characters replaced by U+FFFD
+	Before      float64 `json:"before"`      //plausibility of the text before repair (0 to 1)
+	After       float64 `json:"after"`       //plausibility of the text after repair (0 to 1)
+}
+
+//Repaired returns true if the text is repaired.
+func (r *Result) Repaired() bool {
+	return r != nil && len(r.Chain) > 0
+}
+
+//String method is Stringer for Result.
+func (r *Result) String() string {
+	if !r.Repaired() {
+		return fmt.Sprintf("no mojibake found (plausibility: %.2f)", r.Before)
+	}
+	ss := make([]string, 0, len(r.Chain))
+	for _, s := range r.Chain {
+		ss = append(ss, s.String())
+	}
+	return fmt.Sprintf("%s (plausibility: %.2f -> %.2f, unrecovered: %d)", strings.Join(ss, ", then "), r.Before, r.After, r.Unrecovered)
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
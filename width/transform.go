@@ -0,0 +1,139 @@
+package width
+
+import (
+	"unicode/utf8"
+
+	"github.com/goark/errs"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	wdth "golang.org/x/text/width"
+)
+
+const (
+	voicedMark     = 0x3099 //COMBINING KATAKANA-HIRAGANA VOICED SOUND MARK
+	semiVoicedMark = 0x309a //COMBINING KATAKANA-HIRAGANA SEMI-VOICED SOUND MARK
+)
+
+var (
+	//composeKanaMap is table of kana with (semi-)voiced sound mark (hiragana and katakana)
+	composeKanaMap = map[[2]rune]rune{}
+	//decomposeKanaMap is table of katakana with (semi-)voiced sound mark
+	decomposeKanaMap = map[rune]string{}
+	//halfwidthKanaMap is table of katakana without half-width form
+	halfwidthKanaMap = map[rune]string{
+		'ヮ': "ﾜ", 'ヰ': "ｲ", 'ヱ': "ｴ", 'ヵ': "ｶ", 'ヶ': "ｹ",
+		'ㇰ': "ｸ", 'ㇱ': "ｼ", 'ㇲ': "ｽ", 'ㇳ': "ﾄ", 'ㇴ': "ﾇ", 'ㇵ': "ﾊ", 'ㇶ': "ﾋ", 'ㇷ': "ﾌ",
+		'ㇸ': "ﾍ", 'ㇹ': "ﾎ", 'ㇺ': "ﾑ", 'ㇻ': "ﾗ", 'ㇼ': "ﾘ", 'ㇽ': "ﾙ", 'ㇾ': "ﾚ", 'ㇿ': "ﾛ",
+		0x1b164: "ｲ", 0x1b165: "ｴ", 0x1b166: "ｦ", 0x1b167: "ﾝ",
+	}
+)
+
+func init() {
+	for _, rng := range [][2]rune{{'ぁ', 'ゖ'}, {'ァ', 'ヺ'}} {
+		for r := rng[0]; r <= rng[1]; r++ {
+			rs := []rune(norm.NFD.String(string(r)))
+			if len(rs) != 2 || (rs[1] != voicedMark && rs[1] != semiVoicedMark) {
+				continue
+			}
+			composeKanaMap[[2]rune{rs[0], rs[1]}] = r
+			if rng[0] == 'ァ' {
+				decomposeKanaMap[r] = string(rs)
+			}
+		}
+	}
+}
+
+//NewTransformer returns transform.Transformer instance for converting character width in text stream.
+func NewTransformer(formName string) (transform.Transformer, error) {
+	f, err := FormOf(formName)
+	if err != nil {
+		return nil, errs.Wrap(err, errs.WithContext("formName", formName))
+	}
+	if f == wdth.Narrow {
+		return transform.Chain(&runeMapper{table: decomposeKanaMap}, f, &runeMapper{table: halfwidthKanaMap}), nil
+	}
+	return transform.Chain(f, &kanaComposer{}), nil
+}
+
+//runeMapper is transform.Transformer for replacing characters by table.
+type runeMapper struct {
+	transform.NopResetter
+	table map[rune]string
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *runeMapper) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size <= 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		b := src[nSrc : nSrc+size]
+		if s, ok := t.table[r]; ok && size > 1 {
+			b = []byte(s)
+		}
+		if nDst+len(b) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+//kanaComposer is transform.Transformer for composing kana and (semi-)voiced sound mark.
+//Kana and sound mark split across chunks are also composed.
+type kanaComposer struct {
+	transform.NopResetter
+}
+
+//Transform method is implementation of transform.Transformer interface.
+func (t *kanaComposer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	var buf [utf8.UTFMax]byte
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size <= 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+		b := src[nSrc : nSrc+size]
+		if next := src[nSrc+size:]; isComposable(r) {
+			if !atEOF && !utf8.FullRune(next) {
+				return nDst, nSrc, transform.ErrShortSrc //wait for next character
+			}
+			m, msize := utf8.DecodeRune(next)
+			if c, ok := composeKanaMap[[2]rune{r, m}]; ok {
+				b = buf[:utf8.EncodeRune(buf[:], c)]
+				size += msize
+			}
+		}
+		if nDst+len(b) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], b)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+func isComposable(r rune) bool {
+	_, ok := composeKanaMap[[2]rune{r, voicedMark}]
+	if !ok {
+		_, ok = composeKanaMap[[2]rune{r, semiVoicedMark}]
+	}
+	return ok
+}
+
+/* Copyright 2026 Spiegel
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * 	http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
@@ -1,27 +1,22 @@
 package width
 
 import (
-	"bytes"
 	"io"
-	"strings"
 
 	"github.com/goark/errs"
 	"github.com/goark/kkconv/fold"
+	"golang.org/x/text/transform"
 	wdth "golang.org/x/text/width"
 )
 
 //Convert function converts character width in text stream.
 func Convert(formName string, writer io.Writer, txt io.Reader) error {
-	buf := &bytes.Buffer{}
-	if _, err := buf.ReadFrom(txt); err != nil {
-		return errs.Wrap(err)
-	}
-	str, err := ConvertString(formName, buf.String())
+	t, err := NewTransformer(formName)
 	if err != nil {
 		return errs.Wrap(err)
 	}
-	if _, err := strings.NewReader(str).WriteTo(writer); err != nil {
-		return errs.Wrap(err)
+	if _, err := io.Copy(writer, transform.NewReader(txt, t)); err != nil {
+		return errs.Wrap(err, errs.WithContext("formName", formName))
 	}
 	return nil
 }
@@ -43,7 +38,7 @@ func ConvertString(formName, txt string) (string, error) {
 	return txt, nil
 }
 
-/* Copyright 2020-2021 Spiegel
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goark/errs"
 	"github.com/goark/gnkf/dump"
@@ -77,7 +78,32 @@ func TestTranslate(t *testing.T) {
 	}
 }
 
-/* Copyright 2020 Spiegel
+func TestConvertStream(t *testing.T) {
+	testCases := []string{
+		"ﾍﾟﾝｷﾞﾝ　１２３４５ ヸヹｦﾞ",
+		"ペンギン 12345 ヸヹヺ ヷヴヾ",
+		"か\u3099は\u309aカ\u3099ﾊﾞｶﾞｶﾟ",
+		"ﾞﾟｶﾞﾞ\xff\xe3\x82",
+		"ㇰㇱㇲ𛅤𛅥ヮヵヶ",
+	}
+	for _, formName := range FormList() {
+		for _, tc := range testCases {
+			str, err := ConvertString(formName, tc)
+			if err != nil {
+				t.Errorf("ConvertString(%s) error = \"%+v\", want nil.", formName, err)
+				continue
+			}
+			buf := &bytes.Buffer{}
+			if err := Convert(formName, buf, iotest.OneByteReader(strings.NewReader(tc))); err != nil {
+				t.Errorf("Convert(%s) error = \"%+v\", want nil.", formName, err)
+			} else if buf.String() != str {
+				t.Errorf("Convert(%s, %q) = %q, want %q.", formName, tc, buf.String(), str)
+			}
+		}
+	}
+}
+
+/* Copyright 2020-2026 Spiegel
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.